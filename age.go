@@ -0,0 +1,76 @@
+package govee
+
+import (
+	"fmt"
+	"time"
+)
+
+// now returns the current time. Tests replace it for deterministic results.
+var now = time.Now
+
+// BuildAge returns the time elapsed since the build timestamp, or 0 if the
+// timestamp is not set.
+func (v Version) BuildAge() time.Duration {
+	if v.timestamp.IsZero() {
+		return 0
+	}
+	return now().Sub(v.timestamp)
+}
+
+// BuildAgeHuman returns the build age as coarse human-readable text, such as
+// "3 days ago", in whole seconds, minutes, hours, days or weeks. It returns
+// "unknown" if the timestamp is not set and "in the future" if the timestamp
+// is later than the current time.
+func (v Version) BuildAgeHuman() string {
+	if v.timestamp.IsZero() {
+		return "unknown"
+	}
+
+	age := v.BuildAge()
+	switch {
+	case age < 0:
+		return "in the future"
+	case age < time.Minute:
+		return ago(int(age/time.Second), "second")
+	case age < time.Hour:
+		return ago(int(age/time.Minute), "minute")
+	case age < 24*time.Hour:
+		return ago(int(age/time.Hour), "hour")
+	case age < 7*24*time.Hour:
+		return ago(int(age/(24*time.Hour)), "day")
+	}
+	return ago(int(age/(7*24*time.Hour)), "week")
+}
+
+func ago(n int, unit string) string {
+	if n != 1 {
+		unit += "s"
+	}
+	return fmt.Sprintf("%d %s ago", n, unit)
+}
+
+// TruncateTimestamp returns a copy of the version with its timestamp rounded
+// down to a multiple of d, e.g. time.Hour or 24*time.Hour for day precision.
+// As with time.Time.Truncate, multiples are counted from the zero time, so
+// day precision truncates to midnight UTC.
+func (v Version) TruncateTimestamp(d time.Duration) Version {
+	if !v.timestamp.IsZero() {
+		v.timestamp = v.timestamp.Truncate(d)
+	}
+	return v
+}
+
+// NewerBuildThan reports whether v was built after other, comparing only the
+// build timestamps and ignoring the semantic versions entirely. An unset
+// timestamp is treated as the oldest possible build.
+func (v Version) NewerBuildThan(other Version) bool {
+	return v.timestamp.After(other.timestamp)
+}
+
+// BuiltBefore reports whether the build timestamp is strictly before t, for
+// flagging builds that predate a cutoff such as a security fix. A version
+// without a timestamp returns false, since its build time is unknown; check
+// TStampUnix or the warnings to tell such builds apart.
+func (v Version) BuiltBefore(t time.Time) bool {
+	return !v.timestamp.IsZero() && v.timestamp.Before(t)
+}
@@ -0,0 +1,140 @@
+package govee
+
+import (
+	"testing"
+	"time"
+)
+
+// setNow makes now return t until the test finishes.
+func setNow(t *testing.T, n time.Time) {
+	t.Helper()
+	orig := now
+	now = func() time.Time { return n }
+	t.Cleanup(func() { now = orig })
+}
+
+func TestBuildAge(t *testing.T) {
+	v := testVersion(t, "1.2.3")
+	setNow(t, v.timestamp.Add(90*time.Minute))
+
+	if v.BuildAge() != 90*time.Minute {
+		t.Errorf("Expected %s, got %s", 90*time.Minute, v.BuildAge())
+	}
+	if (Version{}).BuildAge() != 0 {
+		t.Errorf("Expected 0 for an unset timestamp, got %s", (Version{}).BuildAge())
+	}
+}
+
+func TestBuildAgeHuman(t *testing.T) {
+	tests := []struct {
+		age    time.Duration
+		expect string
+	}{
+		{0, "0 seconds ago"},
+		{time.Second, "1 second ago"},
+		{59 * time.Second, "59 seconds ago"},
+		{time.Minute, "1 minute ago"},
+		{59 * time.Minute, "59 minutes ago"},
+		{time.Hour, "1 hour ago"},
+		{23 * time.Hour, "23 hours ago"},
+		{24 * time.Hour, "1 day ago"},
+		{3 * 24 * time.Hour, "3 days ago"},
+		{7 * 24 * time.Hour, "1 week ago"},
+		{20 * 24 * time.Hour, "2 weeks ago"},
+		{-time.Hour, "in the future"},
+	}
+
+	v := testVersion(t, "1.2.3")
+	for _, test := range tests {
+		setNow(t, v.timestamp.Add(test.age))
+		if got := v.BuildAgeHuman(); got != test.expect {
+			t.Errorf("Expected %q for %s, got %q", test.expect, test.age, got)
+		}
+	}
+
+	if got := (Version{}).BuildAgeHuman(); got != "unknown" {
+		t.Errorf("Expected \"unknown\" for an unset timestamp, got %q", got)
+	}
+}
+
+func TestTruncateTimestamp(t *testing.T) {
+	tests := map[time.Duration]string{
+		time.Hour:      "2019-02-14T15:00:00Z",
+		24 * time.Hour: "2019-02-14T00:00:00Z",
+	}
+
+	v := testVersion(t, "1.2.3")
+	for d, expect := range tests {
+		if got := v.TruncateTimestamp(d).TStamp(); got != expect {
+			t.Errorf("Expected %s truncating to %s, got %s", expect, d, got)
+		}
+	}
+	if v.TStamp() != "2019-02-14T15:04:05Z" {
+		t.Errorf("Expected the original to be unchanged, got %s", v.TStamp())
+	}
+}
+
+func TestNewerBuildThan(t *testing.T) {
+	vconf := testConfig()
+	vconf.VersionString = "1.0.0"
+	vconf.TStamp = "Fri Feb 15 15:04:05 UTC 2019"
+	newer, err := NewVersion(&vconf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	older := testVersion(t, "2.0.0")
+
+	if !newer.NewerBuildThan(older) {
+		t.Errorf("Expected %s to be newer than %s", newer.TStamp(), older.TStamp())
+	}
+	if older.NewerBuildThan(newer) {
+		t.Errorf("Expected %s not to be newer than %s", older.TStamp(), newer.TStamp())
+	}
+	if older.NewerBuildThan(older) {
+		t.Error("Expected a build not to be newer than itself")
+	}
+}
+
+func TestNewerBuildThanZero(t *testing.T) {
+	v := testVersion(t, "1.2.3")
+	zero, err := ParseVersionString("1.2.3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !v.NewerBuildThan(zero) {
+		t.Error("Expected a build to be newer than one without a timestamp")
+	}
+	if zero.NewerBuildThan(v) {
+		t.Error("Expected a build without a timestamp not to be newer")
+	}
+}
+
+func TestBuiltBefore(t *testing.T) {
+	v := testVersion(t, "1.2.3")
+	built := time.Date(2019, time.February, 14, 15, 4, 5, 0, time.UTC)
+
+	tests := map[time.Time]bool{
+		built.Add(time.Second):  true,
+		built.Add(-time.Second): false,
+		built:                   false,
+		built.In(time.FixedZone("SAST", 2*60*60)).Add(time.Nanosecond): true,
+	}
+
+	for cutoff, expect := range tests {
+		if v.BuiltBefore(cutoff) != expect {
+			t.Errorf("Expected BuiltBefore(%s) for a build at %s to be %t", cutoff, v.TStamp(), expect)
+		}
+	}
+}
+
+func TestBuiltBeforeZero(t *testing.T) {
+	v, err := ParseVersionString("1.2.3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v.BuiltBefore(time.Now()) {
+		t.Error("Expected a build without a timestamp not to be reported as built before")
+	}
+}
@@ -0,0 +1,283 @@
+package govee
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/blang/semver"
+)
+
+// BumpPre returns a copy of the version with its pre-release counter for
+// label incremented. If the current pre-release is label.N, the result is
+// label.N+1; otherwise the pre-release is set to label.1. The label may
+// contain dots, and each of its identifiers must be a valid, non-numeric
+// semver pre-release identifier.
+func (v Version) BumpPre(label string) (Version, error) {
+	var ids []semver.PRVersion
+	for _, part := range strings.Split(label, ".") {
+		id, err := semver.NewPRVersion(part)
+		if err != nil {
+			return Version{}, fmt.Errorf("govee: invalid pre-release label %q: %s", label, err)
+		}
+		if id.IsNumeric() {
+			return Version{}, fmt.Errorf("govee: invalid pre-release label %q: identifier %q is numeric", label, part)
+		}
+		ids = append(ids, id)
+	}
+
+	var n uint64 = 1
+	pre := v.semver.Pre
+	if len(pre) == len(ids)+1 && pre[len(ids)].IsNumeric() {
+		match := true
+		for i := range ids {
+			if pre[i].Compare(ids[i]) != 0 {
+				match = false
+				break
+			}
+		}
+		if match {
+			n = pre[len(ids)].VersionNum + 1
+		}
+	}
+
+	v.semver.Pre = append(ids, semver.PRVersion{VersionNum: n, IsNum: true})
+	v.noversion = false
+	v.setWarnings()
+	return v, nil
+}
+
+// TargetRelease returns a copy of the version with the pre-release and build
+// metadata removed, i.e. the release that a pre-release such as 2.1.0-rc2 or
+// 2.1.0-dev.5 leads toward. A released version is returned unchanged.
+func (v Version) TargetRelease() Version {
+	v.semver.Pre = nil
+	v.semver.Build = nil
+	v.setWarnings()
+	return v
+}
+
+// NextRC returns the next release candidate for target, target-rc.N, where N
+// is one more than the highest rc.N among existing versions with the same
+// major, minor and patch as target, or 1 if there are none. The pre-release
+// and build metadata of target are ignored; its other fields are kept.
+func NextRC(target Version, existing []Version) Version {
+	var n uint64
+	for _, e := range existing {
+		pre := e.semver.Pre
+		if e.ComparePrecedenceIgnoringPre(target) != 0 || len(pre) != 2 ||
+			pre[0].IsNumeric() || pre[0].VersionStr != "rc" || !pre[1].IsNumeric() {
+			continue
+		}
+		if pre[1].VersionNum > n {
+			n = pre[1].VersionNum
+		}
+	}
+
+	target.semver.Pre = []semver.PRVersion{{VersionStr: "rc"}, {VersionNum: n + 1, IsNum: true}}
+	target.semver.Build = nil
+	target.noversion = false
+	target.setWarnings()
+	return target
+}
+
+// WithBuildMetadata returns a copy of the version with its build metadata
+// replaced by parts, e.g. WithBuildMetadata("ci", "1234") for +ci.1234. Each
+// part must be a valid semver build identifier. Calling it without parts
+// removes the build metadata.
+func (v Version) WithBuildMetadata(parts ...string) (Version, error) {
+	var build []string
+	for _, part := range parts {
+		id, err := semver.NewBuildVersion(part)
+		if err != nil {
+			return Version{}, fmt.Errorf("govee: invalid build metadata %q: %s", part, err)
+		}
+		build = append(build, id)
+	}
+
+	v.semver.Build = build
+	return v, nil
+}
+
+// WithGitInfo returns a copy of the version with its git hash, branch and
+// user replaced, and its warnings recomputed.
+func (v Version) WithGitInfo(hash, branch, user string) Version {
+	v.githash = hash
+	v.gitbranch = branch
+	v.gituser = user
+	v.nobranch = false
+	v.setWarnings()
+	return v
+}
+
+// WithTimestamp returns a copy of the version with its timestamp replaced. Any
+// unparsed timestamp kept under TimestampTolerant is discarded, and the
+// warnings are recomputed.
+func (v Version) WithTimestamp(t time.Time) Version {
+	v.timestamp = t
+	v.badtstamp = ""
+	v.setWarnings()
+	return v
+}
+
+// PreviousPatch returns a copy of the version with the patch number
+// decremented and the pre-release and build metadata cleared, such as 1.2.2
+// for 1.2.3-rc.1. It returns false if the patch number is 0. The result is
+// only the logical predecessor; it may never have been released.
+func (v Version) PreviousPatch() (Version, bool) {
+	if v.semver.Patch == 0 {
+		return Version{}, false
+	}
+	v.semver.Patch--
+	return v.previous(), true
+}
+
+// PreviousMinor returns a copy of the version with the minor number
+// decremented, the patch number reset to 0, and the pre-release and build
+// metadata cleared, such as 1.1.0 for 1.2.3. It returns false if the minor
+// number is 0. Like PreviousPatch, it is a best guess.
+func (v Version) PreviousMinor() (Version, bool) {
+	if v.semver.Minor == 0 {
+		return Version{}, false
+	}
+	v.semver.Minor--
+	v.semver.Patch = 0
+	return v.previous(), true
+}
+
+// PreviousMajor returns a copy of the version with the major number
+// decremented, the minor and patch numbers reset to 0, and the pre-release
+// and build metadata cleared, such as 1.0.0 for 2.3.4. It returns false if
+// the major number is 0. Like PreviousPatch, it is a best guess.
+func (v Version) PreviousMajor() (Version, bool) {
+	if v.semver.Major == 0 {
+		return Version{}, false
+	}
+	v.semver.Major--
+	v.semver.Minor = 0
+	v.semver.Patch = 0
+	return v.previous(), true
+}
+
+// previous clears the pre-release and build metadata after a Previous*
+// method changes the version number.
+func (v Version) previous() Version {
+	v.semver.Pre = nil
+	v.semver.Build = nil
+	v.noversion = false
+	v.setWarnings()
+	return v
+}
+
+// BumpType is the kind of change between two versions.
+type BumpType int
+
+// Bump types. BumpNone to BumpMajor are in increasing order of significance;
+// BumpPrerelease, a change to the pre-release alone, is less significant than
+// BumpPatch but comes last to keep the values of the others stable.
+const (
+	BumpNone BumpType = iota
+	BumpPatch
+	BumpMinor
+	BumpMajor
+	BumpPrerelease
+)
+
+// String returns the name of the bump type.
+func (t BumpType) String() string {
+	switch t {
+	case BumpNone:
+		return "none"
+	case BumpPrerelease:
+		return "prerelease"
+	case BumpPatch:
+		return "patch"
+	case BumpMinor:
+		return "minor"
+	case BumpMajor:
+		return "major"
+	}
+	return fmt.Sprintf("BumpType(%d)", int(t))
+}
+
+// Bump returns a copy of the version with the component selected by t
+// incremented and the lower components reset to zero. The pre-release and
+// build metadata are cleared, except by BumpPrerelease, which increments the
+// trailing numeric identifier of the pre-release, such as 1.2.3-rc.2 for
+// 1.2.3-rc.1, appends .1 if there is none, and starts the next patch at
+// pre-release 0, such as 1.2.4-0 for 1.2.3, if there is no pre-release. Only
+// the build metadata is cleared then. BumpNone returns an unchanged copy.
+func (v Version) Bump(t BumpType) Version {
+	switch t {
+	case BumpPrerelease:
+		if len(v.semver.Pre) == 0 {
+			v.semver.Patch++
+		}
+		v.semver.Pre = nextPre(v.semver.Pre)
+		v.semver.Build = nil
+		v.noversion = false
+		v.setWarnings()
+		return v
+	case BumpMajor:
+		v.semver.Major++
+		v.semver.Minor = 0
+		v.semver.Patch = 0
+	case BumpMinor:
+		v.semver.Minor++
+		v.semver.Patch = 0
+	case BumpPatch:
+		v.semver.Patch++
+	default:
+		return v
+	}
+	v.semver.Pre = nil
+	v.semver.Build = nil
+	v.noversion = false
+	v.setWarnings()
+	return v
+}
+
+// nextPre returns a copy of cur with its trailing numeric identifier
+// incremented, .1 appended if it has none, or the pre-release 0 if cur is
+// empty.
+func nextPre(cur []semver.PRVersion) []semver.PRVersion {
+	n := len(cur)
+	if n == 0 {
+		return []semver.PRVersion{{IsNum: true}}
+	}
+	pre := make([]semver.PRVersion, n, n+1)
+	copy(pre, cur)
+	if pre[n-1].IsNumeric() {
+		pre[n-1].VersionNum++
+		return pre
+	}
+	return append(pre, semver.PRVersion{VersionNum: 1, IsNum: true})
+}
+
+// BumpScope returns the most significant component that changed between from
+// and v, such as BumpMinor for 1.2.3 to 1.3.0. A change to the pre-release
+// alone is BumpPrerelease, and build metadata is ignored.
+func (v Version) BumpScope(from Version) BumpType {
+	switch {
+	case v.semver.Major != from.semver.Major:
+		return BumpMajor
+	case v.semver.Minor != from.semver.Minor:
+		return BumpMinor
+	case v.semver.Patch != from.semver.Patch:
+		return BumpPatch
+	case v.Compare(from) != 0:
+		return BumpPrerelease
+	}
+	return BumpNone
+}
+
+// IsBreakingFrom reports whether upgrading from from to v may break
+// compatibility: the major number increased or, while both are below 1.0.0,
+// the minor number increased, since semver treats every 0.x minor release as
+// potentially breaking. Downgrades are not reported.
+func (v Version) IsBreakingFrom(from Version) bool {
+	if v.semver.Major != from.semver.Major {
+		return v.semver.Major > from.semver.Major
+	}
+	return v.semver.Major == 0 && v.semver.Minor > from.semver.Minor
+}
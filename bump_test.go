@@ -0,0 +1,421 @@
+package govee
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBumpPreFirst(t *testing.T) {
+	expect := "1.4.0-dev.1"
+
+	vconf := testConfig()
+	vconf.VersionString = "1.4.0"
+	v, err := NewVersion(&vconf)
+	if err != nil {
+		t.Error(err)
+	}
+
+	b, err := v.BumpPre("dev")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Semver() != expect {
+		t.Errorf("Expected %s, got %s", expect, b.Semver())
+	}
+	if len(b.Warnings()) != 1 {
+		t.Errorf("Expected 1 warning, got %d", len(b.Warnings()))
+	}
+	if v.Semver() != "1.4.0" {
+		t.Errorf("Expected the original to be unchanged, got %s", v.Semver())
+	}
+}
+
+func TestBumpPreSubsequent(t *testing.T) {
+	expect := "1.4.0-dev.2"
+
+	vconf := testConfig()
+	vconf.VersionString = "1.4.0-dev.1"
+	v, err := NewVersion(&vconf)
+	if err != nil {
+		t.Error(err)
+	}
+
+	b, err := v.BumpPre("dev")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Semver() != expect {
+		t.Errorf("Expected %s, got %s", expect, b.Semver())
+	}
+}
+
+func TestBumpPreSwitchLabel(t *testing.T) {
+	expect := "1.4.0-rc.1"
+
+	vconf := testConfig()
+	vconf.VersionString = "1.4.0-dev.7"
+	v, err := NewVersion(&vconf)
+	if err != nil {
+		t.Error(err)
+	}
+
+	b, err := v.BumpPre("rc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Semver() != expect {
+		t.Errorf("Expected %s, got %s", expect, b.Semver())
+	}
+}
+
+func TestBumpPreInvalidLabel(t *testing.T) {
+	vconf := testConfig()
+	v, err := NewVersion(&vconf)
+	if err != nil {
+		t.Error(err)
+	}
+
+	for _, label := range []string{"", "dev_build", "dev..x", "1"} {
+		if _, err := v.BumpPre(label); err == nil {
+			t.Errorf("Expected an error for label %q", label)
+		}
+	}
+}
+
+func TestTargetRelease(t *testing.T) {
+	tests := map[string]string{
+		"2.1.0-rc2":         "2.1.0",
+		"2.1.0-dev.5":       "2.1.0",
+		"2.1.0-beta+ci.123": "2.1.0",
+		"2.1.0":             "2.1.0",
+	}
+
+	for in, expect := range tests {
+		vconf := testConfig()
+		vconf.VersionString = in
+		v, err := NewVersion(&vconf)
+		if err != nil {
+			t.Error(err)
+		}
+
+		r := v.TargetRelease()
+		if r.Semver() != expect {
+			t.Errorf("Expected %s, got %s", expect, r.Semver())
+		}
+		if len(r.Warnings()) != 0 {
+			t.Errorf("Expected no warnings for %s, got %v", r, r.Warnings())
+		}
+	}
+}
+
+func TestNextRC(t *testing.T) {
+	tests := []struct {
+		target   string
+		existing []string
+		expect   string
+	}{
+		{"1.3.0", nil, "1.3.0-rc.1"},
+		{"1.3.0", []string{"1.2.0-rc.4", "1.2.0", "1.3.0-beta.2"}, "1.3.0-rc.1"},
+		{"1.3.0", []string{"1.3.0-rc.1", "1.3.0-rc.3", "1.3.0-rc.2"}, "1.3.0-rc.4"},
+		{"1.3.0", []string{"1.3.0-rc.2+ci.9", "1.3.1-rc.7", "1.3.0-rc.x"}, "1.3.0-rc.3"},
+		{"1.3.0-rc.1+ci.1", []string{"1.3.0-rc.1"}, "1.3.0-rc.2"},
+	}
+
+	for _, test := range tests {
+		target := testVersion(t, test.target)
+		got := NextRC(target, testVersions(t, test.existing...))
+		if got.Semver() != test.expect {
+			t.Errorf("Expected %s for %s after %v, got %s", test.expect, test.target, test.existing, got)
+		}
+		if got.GitHash() != target.GitHash() {
+			t.Errorf("Expected the git hash to be kept, got %s", got.GitHash())
+		}
+	}
+}
+
+func TestWithBuildMetadata(t *testing.T) {
+	expect := "1.2.3-rc.1+ci.1234"
+
+	v := testVersion(t, "1.2.3-rc.1")
+	b, err := v.WithBuildMetadata("ci", "1234")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Semver() != expect {
+		t.Errorf("Expected %s, got %s", expect, b.Semver())
+	}
+	if v.Semver() != "1.2.3-rc.1" {
+		t.Errorf("Expected the original to be unchanged, got %s", v.Semver())
+	}
+}
+
+func TestWithBuildMetadataReplace(t *testing.T) {
+	expect := "1.2.3+ci.5678"
+
+	v := testVersion(t, "1.2.3+ci.1234.linux")
+	b, err := v.WithBuildMetadata("ci", "5678")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Semver() != expect {
+		t.Errorf("Expected %s, got %s", expect, b.Semver())
+	}
+}
+
+func TestWithBuildMetadataInvalid(t *testing.T) {
+	v := testVersion(t, "1.2.3")
+
+	for _, part := range []string{"", "ci_1234", "ci.1234"} {
+		if _, err := v.WithBuildMetadata(part); err == nil {
+			t.Errorf("Expected an error for %q", part)
+		}
+	}
+}
+
+func TestWithGitInfo(t *testing.T) {
+	v, err := ParseVersionString("1.2.3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g := v.WithGitInfo("de6e4f2", "master", "Jane Doe")
+	if g.GitHash() != "de6e4f2" || g.GitBranch() != "master" || g.GitUser() != "Jane Doe" {
+		t.Errorf("Expected de6e4f2/master/Jane Doe, got %s/%s/%s", g.GitHash(), g.GitBranch(), g.GitUser())
+	}
+	if v.GitHash() != "" || v.GitBranch() != "" || v.GitUser() != "" {
+		t.Errorf("Expected the original to be unchanged, got %#v", v)
+	}
+}
+
+func TestWithGitInfoWarnings(t *testing.T) {
+	v, err := ParseVersionString("1.2.3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g := v.WithGitInfo("unknown", "master", "Jane Doe")
+	if len(g.Warnings()) != len(v.Warnings())+1 {
+		t.Errorf("Expected an invalid git hash warning, got %v", g.Warnings())
+	}
+}
+
+func TestWithTimestamp(t *testing.T) {
+	v, err := ParseVersionString("1.2.3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ts := time.Date(2019, time.February, 14, 15, 4, 5, 0, time.UTC)
+	w := v.WithTimestamp(ts)
+	if !w.TStampTime().Equal(ts) {
+		t.Errorf("Expected %s, got %s", ts, w.TStampTime())
+	}
+	if w.TStamp() != "2019-02-14T15:04:05Z" {
+		t.Errorf("Expected 2019-02-14T15:04:05Z, got %s", w.TStamp())
+	}
+	if w.TStampUnix() != ts.Unix() {
+		t.Errorf("Expected %d, got %d", ts.Unix(), w.TStampUnix())
+	}
+	if !v.TStampTime().IsZero() {
+		t.Errorf("Expected the original to be unchanged, got %s", v.TStampTime())
+	}
+}
+
+func TestWithTimestampClearsInvalid(t *testing.T) {
+	vconf := testConfig()
+	vconf.TStamp = "yesterday"
+	vconf.TimestampTolerant = true
+	v, err := NewVersion(&vconf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := v.WithTimestamp(time.Now())
+	if len(w.Warnings()) != len(v.Warnings())-1 {
+		t.Errorf("Expected the invalid timestamp warning to be cleared, got %v", w.Warnings())
+	}
+}
+
+func TestPrevious(t *testing.T) {
+	tests := []struct {
+		version             string
+		patch, minor, major string
+	}{
+		{"2.3.4", "2.3.3", "2.2.0", "1.0.0"},
+		{"1.2.3-rc.1+ci.1", "1.2.2", "1.1.0", "0.0.0"},
+		{"1.2.0", "", "1.1.0", "0.0.0"},
+		{"1.0.3", "1.0.2", "", "0.0.0"},
+		{"0.0.1", "0.0.0", "", ""},
+		{"0.0.0", "", "", ""},
+	}
+
+	for _, test := range tests {
+		v := testVersion(t, test.version)
+		for name, f := range map[string]func() (Version, bool){
+			"patch": v.PreviousPatch,
+			"minor": v.PreviousMinor,
+			"major": v.PreviousMajor,
+		} {
+			expect := map[string]string{"patch": test.patch, "minor": test.minor, "major": test.major}[name]
+			got, ok := f()
+			if ok != (expect != "") {
+				t.Errorf("Expected previous %s of %s to exist: %t", name, test.version, expect != "")
+				continue
+			}
+			if ok && got.Semver() != expect {
+				t.Errorf("Expected previous %s of %s to be %s, got %s", name, test.version, expect, got)
+			}
+		}
+		if v.Semver() != test.version {
+			t.Errorf("Expected the original to be unchanged, got %s", v)
+		}
+	}
+}
+
+func TestBump(t *testing.T) {
+	tests := []struct {
+		bump   BumpType
+		expect string
+	}{
+		{BumpMajor, "2.0.0"},
+		{BumpMinor, "1.3.0"},
+		{BumpPatch, "1.2.4"},
+		{BumpPrerelease, "1.2.3-rc.2"},
+		{BumpNone, "1.2.3-rc.1+ci.1"},
+	}
+
+	v := testVersion(t, "1.2.3-rc.1+ci.1")
+	for _, test := range tests {
+		if got := v.Bump(test.bump); got.Semver() != test.expect {
+			t.Errorf("Expected %s bump of %s to be %s, got %s", test.bump, v, test.expect, got)
+		}
+	}
+	if v.Semver() != "1.2.3-rc.1+ci.1" {
+		t.Errorf("Expected the original to be unchanged, got %s", v)
+	}
+}
+
+func TestBumpPrerelease(t *testing.T) {
+	tests := []struct {
+		version string
+		expect  string
+	}{
+		{"1.2.3-rc.9", "1.2.3-rc.10"},
+		{"1.2.3-beta", "1.2.3-beta.1"},
+		{"1.2.3-0", "1.2.3-1"},
+		{"1.2.3+ci.1", "1.2.4-0"},
+	}
+
+	for _, test := range tests {
+		v := testVersion(t, test.version)
+		got := v.Bump(BumpPrerelease)
+		if got.Semver() != test.expect {
+			t.Errorf("Expected prerelease bump of %s to be %s, got %s", test.version, test.expect, got)
+		}
+		if !v.Less(got) {
+			t.Errorf("Expected %s to precede %s", v, got)
+		}
+		if v.Semver() != test.version {
+			t.Errorf("Expected the original to be unchanged, got %s", v)
+		}
+	}
+}
+
+func TestBumpTypeValues(t *testing.T) {
+	if BumpPatch != 1 || BumpMinor != 2 || BumpMajor != 3 || BumpPrerelease != 4 {
+		t.Errorf("Expected BumpPatch, BumpMinor, BumpMajor and BumpPrerelease to be 1 to 4, got %d, %d, %d and %d",
+			BumpPatch, BumpMinor, BumpMajor, BumpPrerelease)
+	}
+	if BumpPrerelease.String() != "prerelease" {
+		t.Errorf("Expected prerelease, got %s", BumpPrerelease)
+	}
+}
+
+func TestBumpWarnings(t *testing.T) {
+	v := testVersion(t, "1.2.3-rc.1")
+
+	if len(v.Bump(BumpPatch).Warnings()) != 0 {
+		t.Errorf("Expected no pre-release warning after a bump, got %v", v.Bump(BumpPatch).Warnings())
+	}
+	if len(v.Bump(BumpNone).Warnings()) != 1 {
+		t.Errorf("Expected the pre-release warning to remain, got %v", v.Bump(BumpNone).Warnings())
+	}
+}
+
+func TestBumpScope(t *testing.T) {
+	tests := []struct {
+		from, to string
+		expect   BumpType
+	}{
+		{"1.2.3", "2.0.0", BumpMajor},
+		{"1.2.3", "1.3.0", BumpMinor},
+		{"1.2.3", "1.2.4", BumpPatch},
+		{"1.2.3-rc.1", "1.2.3-rc.2", BumpPrerelease},
+		{"1.2.3-rc.1", "1.2.3", BumpPrerelease},
+		{"1.2.3-rc.1", "1.3.0-rc.1", BumpMinor},
+		{"1.2.3+ci.1", "1.2.3+ci.2", BumpNone},
+		{"1.2.3", "1.2.3", BumpNone},
+	}
+
+	for _, test := range tests {
+		to := testVersion(t, test.to)
+		if got := to.BumpScope(testVersion(t, test.from)); got != test.expect {
+			t.Errorf("Expected %s from %s to %s, got %s", test.expect, test.from, test.to, got)
+		}
+	}
+}
+
+func TestIsBreakingFrom(t *testing.T) {
+	tests := []struct {
+		from, to string
+		expect   bool
+	}{
+		{"1.2.3", "2.0.0", true},
+		{"0.9.1", "1.0.0", true},
+		{"0.2.3", "0.3.0", true},
+		{"1.2.3", "1.3.0", false},
+		{"1.2.3", "1.2.4", false},
+		{"0.2.3", "0.2.4", false},
+		{"2.0.0-rc.1", "2.0.0", false},
+		{"2.0.0", "1.9.0", false},
+		{"0.3.0", "0.2.0", false},
+	}
+
+	for _, test := range tests {
+		to := testVersion(t, test.to)
+		if got := to.IsBreakingFrom(testVersion(t, test.from)); got != test.expect {
+			t.Errorf("Expected IsBreakingFrom %s to %s to be %t", test.from, test.to, test.expect)
+		}
+	}
+}
+
+func TestBumpTolerantEmptyVersion(t *testing.T) {
+	vconf := testConfig()
+	vconf.VersionString = ""
+	vconf.VersionTolerant = true
+	v, err := NewVersion(&vconf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pre, err := v.BumpPre("dev")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, b := range []Version{v.Bump(BumpMinor), pre, NextRC(v, nil)} {
+		for _, w := range b.StructuredWarnings() {
+			if w.Code == WarningEmptyVersion {
+				t.Errorf("Expected no empty version warning after changing the version to %s", b)
+			}
+		}
+
+		c := b.Config()
+		got, err := NewVersion(&c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Semver() != b.Semver() || !got.EqualFull(b) {
+			t.Errorf("Expected %s to round-trip through Config, got %s", b, got)
+		}
+	}
+}
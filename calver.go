@@ -0,0 +1,24 @@
+package govee
+
+import "time"
+
+// IsCalVer reports whether the version looks like a calendar version such as
+// 2024.02.14, judged by whether the major number is a four digit year.
+func (v Version) IsCalVer() bool {
+	return v.semver.Major >= 1000 && v.semver.Major <= 9999
+}
+
+// CalVerDate interprets the major, minor and patch numbers as year, month and
+// day. It returns false if the version is not a calendar version or the
+// components do not form a valid date.
+func (v Version) CalVerDate() (time.Time, bool) {
+	if !v.IsCalVer() || v.semver.Minor < 1 || v.semver.Minor > 12 || v.semver.Patch < 1 || v.semver.Patch > 31 {
+		return time.Time{}, false
+	}
+	year, month, day := int(v.semver.Major), time.Month(v.semver.Minor), int(v.semver.Patch)
+	d := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	if d.Month() != month || d.Day() != day {
+		return time.Time{}, false
+	}
+	return d, true
+}
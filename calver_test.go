@@ -0,0 +1,56 @@
+package govee
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalVer(t *testing.T) {
+	expect := time.Date(2024, time.February, 14, 0, 0, 0, 0, time.UTC)
+
+	vconf := testConfig()
+	vconf.VersionString = "2024.2.14"
+	v, err := NewVersion(&vconf)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !v.IsCalVer() {
+		t.Errorf("Expected %s to be a calendar version", v)
+	}
+	d, ok := v.CalVerDate()
+	if !ok {
+		t.Fatalf("Expected %s to have a date", v)
+	}
+	if !d.Equal(expect) {
+		t.Errorf("Expected %s, got %s", expect, d)
+	}
+}
+
+func TestCalVerInvalidDate(t *testing.T) {
+	vconf := testConfig()
+	vconf.VersionString = "2023.2.30"
+	v, err := NewVersion(&vconf)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if _, ok := v.CalVerDate(); ok {
+		t.Errorf("Expected %s not to have a date", v)
+	}
+}
+
+func TestNotCalVer(t *testing.T) {
+	vconf := testConfig()
+	v, err := NewVersion(&vconf)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if v.IsCalVer() {
+		t.Errorf("Expected %s not to be a calendar version", v)
+	}
+	if _, ok := v.CalVerDate(); ok {
+		t.Errorf("Expected %s not to have a date", v)
+	}
+}
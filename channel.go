@@ -0,0 +1,43 @@
+package govee
+
+import "strings"
+
+// Release channels returned by Version.Channel.
+const (
+	ChannelStable  = "stable"
+	ChannelBeta    = "beta"
+	ChannelAlpha   = "alpha"
+	ChannelNightly = "nightly"
+	ChannelDev     = "dev"
+)
+
+// Channel classifies the version into a release channel.
+//
+// If the version has a pre-release, its first identifier decides the
+// channel: identifiers starting with "rc" or "beta" map to beta, "alpha" to
+// alpha and "nightly" to nightly. Any other pre-release, including git
+// describe output such as 2-ga1b2c3d, maps to dev.
+//
+// Without a pre-release, a release label naming one of the beta, alpha,
+// nightly or dev channels selects that channel, and everything else is
+// stable.
+func (v Version) Channel() string {
+	if len(v.semver.Pre) > 0 {
+		id := strings.ToLower(v.semver.Pre[0].String())
+		switch {
+		case strings.HasPrefix(id, "rc"), strings.HasPrefix(id, "beta"):
+			return ChannelBeta
+		case strings.HasPrefix(id, "alpha"):
+			return ChannelAlpha
+		case strings.HasPrefix(id, "nightly"):
+			return ChannelNightly
+		}
+		return ChannelDev
+	}
+
+	switch release := strings.ToLower(v.release); release {
+	case ChannelBeta, ChannelAlpha, ChannelNightly, ChannelDev:
+		return release
+	}
+	return ChannelStable
+}
@@ -0,0 +1,32 @@
+package govee
+
+import "testing"
+
+func TestChannel(t *testing.T) {
+	tests := []struct {
+		version, release, expect string
+	}{
+		{"1.2.3", "prod", ChannelStable},
+		{"1.2.3", "test", ChannelStable},
+		{"1.2.3", "nightly", ChannelNightly},
+		{"1.2.3-rc2", "prod", ChannelBeta},
+		{"1.2.3-beta.1", "prod", ChannelBeta},
+		{"1.2.3-alpha", "prod", ChannelAlpha},
+		{"1.2.3-nightly.20190214", "test", ChannelNightly},
+		{"1.2.3-dev.5", "test", ChannelDev},
+		{"1.2.3-2-ga1b2c3d", "prod", ChannelDev},
+	}
+
+	for _, test := range tests {
+		vconf := testConfig()
+		vconf.VersionString = test.version
+		vconf.Release = test.release
+		v, err := NewVersion(&vconf)
+		if err != nil {
+			t.Error(err)
+		}
+		if v.Channel() != test.expect {
+			t.Errorf("Expected %s for %s (%s), got %s", test.expect, test.version, test.release, v.Channel())
+		}
+	}
+}
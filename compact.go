@@ -0,0 +1,51 @@
+package govee
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// base62 is the digit alphabet of compact tokens.
+const base62 = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// CompactToken returns NumericVersion encoded in base 62, such as "4CfL" for
+// 1.2.3, for short URLs and similar identifiers. It inherits the limits of
+// NumericVersion: the token only identifies the version while minor and patch
+// are below 1000.
+func (v Version) CompactToken() string {
+	n := v.NumericVersion()
+	if n <= 0 {
+		return "0"
+	}
+
+	var b []byte
+	for ; n > 0; n /= 62 {
+		b = append(b, base62[n%62])
+	}
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+	return string(b)
+}
+
+// ParseCompactToken decodes a token produced by CompactToken into the major,
+// minor and patch numbers.
+func ParseCompactToken(s string) (major, minor, patch int, err error) {
+	if s == "" {
+		return 0, 0, 0, fmt.Errorf("govee: empty compact token")
+	}
+
+	var n int64
+	for _, r := range s {
+		d := strings.IndexRune(base62, r)
+		if d < 0 {
+			return 0, 0, 0, fmt.Errorf("govee: invalid compact token %q: unexpected %q", s, r)
+		}
+		if n > (math.MaxInt64-int64(d))/62 {
+			return 0, 0, 0, fmt.Errorf("govee: invalid compact token %q: out of range", s)
+		}
+		n = n*62 + int64(d)
+	}
+	return int(n / 1000000), int(n / 1000 % 1000), int(n % 1000), nil
+}
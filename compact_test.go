@@ -0,0 +1,40 @@
+package govee
+
+import "testing"
+
+func TestCompactToken(t *testing.T) {
+	tests := map[string]string{
+		"0.0.0":       "0",
+		"0.0.1":       "1",
+		"1.2.3":       "4CfL",
+		"1.2.3-rc.1":  "4CfL",
+		"10.20.30":    "g2fO",
+		"2019.2.14":   "2CdWec",
+		"0.999.999":   "4C91",
+		"1.0.0+ci.42": "4C92",
+	}
+
+	for s, expect := range tests {
+		v := testVersion(t, s)
+		token := v.CompactToken()
+		if token != expect {
+			t.Errorf("Expected %s for %s, got %s", expect, s, token)
+		}
+
+		major, minor, patch, err := ParseCompactToken(token)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if major != v.Major() || minor != v.Minor() || patch != v.Patch() {
+			t.Errorf("Expected %d.%d.%d from %s, got %d.%d.%d", v.Major(), v.Minor(), v.Patch(), token, major, minor, patch)
+		}
+	}
+}
+
+func TestParseCompactTokenInvalid(t *testing.T) {
+	for _, s := range []string{"", "5I-3", "5I 3", "zzzzzzzzzzzzzzzzzzzz"} {
+		if _, _, _, err := ParseCompactToken(s); err == nil {
+			t.Errorf("Expected an error for %q", s)
+		}
+	}
+}
@@ -0,0 +1,318 @@
+package govee
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/blang/semver"
+)
+
+// IsSupported reports whether the version falls within a support window of
+// minorsBack minor versions behind latest. A minorsBack of 0 supports only
+// the latest minor version, 1 supports the latest two, and so on, and a
+// negative minorsBack is treated as 0. Versions in a different major line are
+// unsupported, while a version newer than latest in the same major line is
+// supported.
+func (v Version) IsSupported(latest Version, minorsBack int) bool {
+	if v.semver.Major != latest.semver.Major {
+		return false
+	}
+	if v.semver.Minor >= latest.semver.Minor {
+		return true
+	}
+	if minorsBack < 0 {
+		return false
+	}
+	return latest.semver.Minor-v.semver.Minor <= uint64(minorsBack)
+}
+
+// NumericVersion encodes the major, minor and patch numbers as a single
+// integer, major*1000000 + minor*1000 + patch, so that versions can be
+// ordered with a plain integer comparison. Pre-release and build metadata are
+// ignored. The encoding is only monotonic while minor and patch are below
+// 1000 and major is below 9223372036854; outside those ranges components
+// overlap or the result overflows.
+func (v Version) NumericVersion() int64 {
+	return int64(v.semver.Major)*1000000 + int64(v.semver.Minor)*1000 + int64(v.semver.Patch)
+}
+
+// Max returns the version with the higher precedence. If a and b have equal
+// precedence, a is returned.
+func Max(a, b Version) Version {
+	if b.semver.GT(a.semver) {
+		return b
+	}
+	return a
+}
+
+// Min returns the version with the lower precedence. If a and b have equal
+// precedence, a is returned.
+func Min(a, b Version) Version {
+	if b.semver.LT(a.semver) {
+		return b
+	}
+	return a
+}
+
+// SameReleaseDifferentBuild reports whether v and other share the same
+// major, minor, patch and pre-release, but differ in their build metadata,
+// i.e. whether other is a rebuild of the same release.
+func (v Version) SameReleaseDifferentBuild(other Version) bool {
+	return v.semver.Compare(other.semver) == 0 && v.semver.String() != other.semver.String()
+}
+
+// Distance returns the absolute differences between the major, minor and
+// patch numbers of v and other.
+func (v Version) Distance(other Version) (major, minor, patch int) {
+	return absDiff(v.semver.Major, other.semver.Major),
+		absDiff(v.semver.Minor, other.semver.Minor),
+		absDiff(v.semver.Patch, other.semver.Patch)
+}
+
+func absDiff(a, b uint64) int {
+	if a > b {
+		return int(a - b)
+	}
+	return int(b - a)
+}
+
+// ComparePrecedenceIgnoringPre compares v and other by major, minor and patch
+// only, returning -1, 0 or 1. Unlike semver precedence, pre-releases are
+// ignored, so 2.0.0-rc.1 and 2.0.0 compare as equal.
+func (v Version) ComparePrecedenceIgnoringPre(other Version) int {
+	a := semver.Version{Major: v.semver.Major, Minor: v.semver.Minor, Patch: v.semver.Patch}
+	b := semver.Version{Major: other.semver.Major, Minor: other.semver.Minor, Patch: other.semver.Patch}
+	return a.Compare(b)
+}
+
+// Compare compares v and other by semver precedence, returning -1, 0 or 1.
+// Build metadata is ignored.
+func (v Version) Compare(other Version) int {
+	return v.semver.Compare(other.semver)
+}
+
+// Less reports whether v has a lower precedence than other.
+func (v Version) Less(other Version) bool {
+	return v.Compare(other) < 0
+}
+
+// Comparison is the detailed result of Version.CompareDetailed.
+type Comparison struct {
+	Order     int  // -1, 0 or 1, as returned by Compare.
+	SameCore  bool // major, minor and patch are equal.
+	SamePre   bool // pre-releases are equal, or both absent.
+	SameBuild bool // build metadata is equal, or both absent.
+}
+
+// CompareDetailed compares v and other like Compare, and also reports which
+// parts of the two versions match.
+func (v Version) CompareDetailed(other Version) Comparison {
+	pre := len(v.semver.Pre) == len(other.semver.Pre)
+	for i := 0; pre && i < len(v.semver.Pre); i++ {
+		pre = v.semver.Pre[i].Compare(other.semver.Pre[i]) == 0
+	}
+	build := len(v.semver.Build) == len(other.semver.Build)
+	for i := 0; build && i < len(v.semver.Build); i++ {
+		build = v.semver.Build[i] == other.semver.Build[i]
+	}
+	return Comparison{
+		Order:     v.Compare(other),
+		SameCore:  v.ComparePrecedenceIgnoringPre(other) == 0,
+		SamePre:   pre,
+		SameBuild: build,
+	}
+}
+
+// Comparator compares versions by semver precedence with an optional policy
+// for ordering pre-release labels.
+type Comparator struct {
+	// PreOrder ranks pre-release labels from lowest to highest, such as
+	// {"dev", "alpha", "beta", "rc"}. The label is the first identifier of
+	// the pre-release, and unlisted labels rank below every listed one.
+	// When two versions have the same major, minor and patch and their
+	// labels rank differently, the rank decides the order; otherwise semver
+	// precedence applies.
+	PreOrder []string
+}
+
+// Compare compares a and b, returning -1, 0 or 1. Build metadata is ignored.
+func (c Comparator) Compare(a, b Version) int {
+	if cmp := a.ComparePrecedenceIgnoringPre(b); cmp != 0 {
+		return cmp
+	}
+	if len(a.semver.Pre) > 0 && len(b.semver.Pre) > 0 {
+		ra, rb := c.preRank(a), c.preRank(b)
+		if ra != rb {
+			if ra < rb {
+				return -1
+			}
+			return 1
+		}
+	}
+	return a.Compare(b)
+}
+
+// preRank returns the index of the version's pre-release label in PreOrder,
+// or -1 if it isn't listed.
+func (c Comparator) preRank(v Version) int {
+	id := v.semver.Pre[0]
+	if id.IsNumeric() {
+		return -1
+	}
+	for i, label := range c.PreOrder {
+		if label == id.VersionStr {
+			return i
+		}
+	}
+	return -1
+}
+
+// Sort sorts versions in ascending order of precedence. Versions with equal
+// precedence are ordered by git hash and then by timestamp, and the sort is
+// stable, so the result is deterministic.
+func Sort(vs []Version) {
+	sort.SliceStable(vs, func(i, j int) bool {
+		if c := vs[i].Compare(vs[j]); c != 0 {
+			return c < 0
+		}
+		if vs[i].githash != vs[j].githash {
+			return vs[i].githash < vs[j].githash
+		}
+		return vs[i].timestamp.Before(vs[j].timestamp)
+	})
+}
+
+// SortStrings sorts version strings in place in ascending order of
+// precedence, keeping the original strings. Strings with equal precedence
+// keep their relative order. If any string is not a valid version, an error
+// wrapping ErrInvalidVersion is returned and ss is left unmodified.
+func SortStrings(ss []string) error {
+	vs := make([]semver.Version, len(ss))
+	for i, s := range ss {
+		v, err := ParseVersionString(s)
+		if err != nil {
+			return fmt.Errorf("govee: sorting version %d: %w", i, err)
+		}
+		vs[i] = v.semver
+	}
+
+	idx := make([]int, len(ss))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool {
+		return vs[idx[i]].LT(vs[idx[j]])
+	})
+
+	sorted := make([]string, len(ss))
+	for i, n := range idx {
+		sorted[i] = ss[n]
+	}
+	copy(ss, sorted)
+	return nil
+}
+
+// IsMonotonic reports whether vs is in strictly ascending order of
+// precedence. If it is not, the index of the first version that does not
+// exceed its predecessor is returned; otherwise the index is -1.
+func IsMonotonic(vs []Version) (bool, int) {
+	for i := 1; i < len(vs); i++ {
+		if vs[i].Compare(vs[i-1]) <= 0 {
+			return false, i
+		}
+	}
+	return true, -1
+}
+
+// ErrVersionTooOld is returned, wrapped, by RequireAtLeastVersion.
+var ErrVersionTooOld = errors.New("govee: version too old")
+
+// RequireAtLeastVersion returns an error wrapping ErrVersionTooOld if v has a
+// lower precedence than min, naming both versions, and nil otherwise. It lets
+// a library refuse to run inside a host application older than it supports.
+func (v Version) RequireAtLeastVersion(min Version) error {
+	if v.Less(min) {
+		return fmt.Errorf("%w: %s is older than the required minimum %s", ErrVersionTooOld, v, min)
+	}
+	return nil
+}
+
+// Negotiate returns the highest version supported by both the client and the
+// server, and false if they have no version in common. Versions are matched
+// by precedence, so build metadata is ignored, and the client's version is
+// returned.
+func Negotiate(clientSupported, serverSupported []Version) (Version, bool) {
+	var best Version
+	found := false
+	for _, c := range clientSupported {
+		if found && c.Compare(best) <= 0 {
+			continue
+		}
+		for _, s := range serverSupported {
+			if c.Compare(s) == 0 {
+				best, found = c, true
+				break
+			}
+		}
+	}
+	return best, found
+}
+
+// GroupByMajor returns the versions grouped by major number, with each group
+// sorted as by Sort.
+func GroupByMajor(vs []Version) map[int][]Version {
+	groups := make(map[int][]Version)
+	for _, v := range vs {
+		groups[v.Major()] = append(groups[v.Major()], v)
+	}
+	for _, group := range groups {
+		Sort(group)
+	}
+	return groups
+}
+
+// LatestStable returns the highest precedence version that is not a
+// pre-release, and false if there is none.
+func LatestStable(vs []Version) (Version, bool) {
+	var latest Version
+	found := false
+	for _, v := range vs {
+		if len(v.semver.Pre) > 0 {
+			continue
+		}
+		if !found || v.Compare(latest) > 0 {
+			latest, found = v, true
+		}
+	}
+	return latest, found
+}
+
+// SameSourceBuild reports whether all versions were built from the same
+// source, i.e. share the same semver string and git hash and differ at most
+// in other fields such as OS and architecture. If not, it also returns the
+// Range keys of the fields that differ: "version", "git_hash" or both.
+func SameSourceBuild(vs []Version) (bool, []string) {
+	var mismatches []string
+	for _, v := range vs {
+		if v.semver.String() != vs[0].semver.String() {
+			mismatches = append(mismatches, "version")
+			break
+		}
+	}
+	for _, v := range vs {
+		if v.githash != vs[0].githash {
+			mismatches = append(mismatches, "git_hash")
+			break
+		}
+	}
+	return len(mismatches) == 0, mismatches
+}
+
+// IsPreReleaseOf reports whether v is a pre-release leading to release, e.g.
+// 2.0.0-rc.2 for 2.0.0. Both must have the same major, minor and patch, v
+// must have a pre-release and release must not.
+func (v Version) IsPreReleaseOf(release Version) bool {
+	return len(v.semver.Pre) > 0 && len(release.semver.Pre) == 0 &&
+		v.ComparePrecedenceIgnoringPre(release) == 0
+}
@@ -0,0 +1,595 @@
+package govee
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// testVersion returns a version constructed from testConfig with the given
+// version string.
+func testVersion(t *testing.T, s string) Version {
+	t.Helper()
+	vconf := testConfig()
+	vconf.VersionString = s
+	v, err := NewVersion(&vconf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestIsSupported(t *testing.T) {
+	latest := testVersion(t, "2.5.3")
+
+	tests := []struct {
+		version string
+		expect  bool
+	}{
+		{"2.5.0", true},
+		{"2.4.9", true},
+		{"2.3.7", false},
+		{"2.6.0", true},
+		{"1.5.3", false},
+		{"3.0.0", false},
+	}
+
+	for _, test := range tests {
+		v := testVersion(t, test.version)
+		if v.IsSupported(latest, 1) != test.expect {
+			t.Errorf("Expected IsSupported(%s, 1) for %s to be %t", latest, v, test.expect)
+		}
+	}
+}
+
+func TestIsSupportedNegative(t *testing.T) {
+	latest := testVersion(t, "2.5.3")
+
+	tests := map[string]bool{
+		"2.5.0": true,
+		"2.6.0": true,
+		"2.4.9": false,
+		"2.0.0": false,
+	}
+
+	for version, expect := range tests {
+		v := testVersion(t, version)
+		if v.IsSupported(latest, -1) != expect {
+			t.Errorf("Expected IsSupported(%s, -1) for %s to be %t", latest, v, expect)
+		}
+	}
+}
+
+func TestNumericVersion(t *testing.T) {
+	tests := map[string]int64{
+		"0.0.0":        0,
+		"0.0.1":        1,
+		"1.2.3":        1002003,
+		"1.2.3-rc.1":   1002003,
+		"10.20.30":     10020030,
+		"0.999.999":    999999,
+		"1.0.0":        1000000,
+		"2147.483.647": 2147483647,
+		"9000.999.999": 9000999999,
+	}
+
+	for in, expect := range tests {
+		v := testVersion(t, in)
+		if v.NumericVersion() != expect {
+			t.Errorf("Expected %d for %s, got %d", expect, in, v.NumericVersion())
+		}
+	}
+}
+
+func TestMaxMin(t *testing.T) {
+	tests := []struct {
+		a, b, max, min string
+	}{
+		{"1.2.3", "1.3.0", "1.3.0", "1.2.3"},
+		{"2.0.0", "1.9.9", "2.0.0", "1.9.9"},
+		{"2.0.0-rc.1", "2.0.0", "2.0.0", "2.0.0-rc.1"},
+		{"2.0.0-alpha", "2.0.0-beta", "2.0.0-beta", "2.0.0-alpha"},
+	}
+
+	for _, test := range tests {
+		a, b := testVersion(t, test.a), testVersion(t, test.b)
+		if got := Max(a, b); got.Semver() != test.max {
+			t.Errorf("Expected Max(%s, %s) to be %s, got %s", a, b, test.max, got)
+		}
+		if got := Min(a, b); got.Semver() != test.min {
+			t.Errorf("Expected Min(%s, %s) to be %s, got %s", a, b, test.min, got)
+		}
+	}
+}
+
+func TestMaxMinTie(t *testing.T) {
+	a := testVersion(t, "1.2.3+build.1")
+	b := testVersion(t, "1.2.3+build.2")
+
+	if got := Max(a, b); got.Semver() != a.Semver() {
+		t.Errorf("Expected Max to return the first argument %s, got %s", a, got)
+	}
+	if got := Min(a, b); got.Semver() != a.Semver() {
+		t.Errorf("Expected Min to return the first argument %s, got %s", a, got)
+	}
+}
+
+func TestSameReleaseDifferentBuild(t *testing.T) {
+	tests := []struct {
+		a, b   string
+		expect bool
+	}{
+		{"1.2.3+ci.1", "1.2.3+ci.1", false},
+		{"1.2.3+ci.1", "1.2.3+ci.2", true},
+		{"1.2.3-rc.1+ci.1", "1.2.3-rc.1", true},
+		{"1.2.3+ci.1", "1.2.4+ci.2", false},
+		{"1.2.3-rc.1+ci.1", "1.2.3-rc.2+ci.2", false},
+	}
+
+	for _, test := range tests {
+		a, b := testVersion(t, test.a), testVersion(t, test.b)
+		if a.SameReleaseDifferentBuild(b) != test.expect {
+			t.Errorf("Expected SameReleaseDifferentBuild(%s, %s) to be %t", a, b, test.expect)
+		}
+	}
+}
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		a, b                string
+		major, minor, patch int
+	}{
+		{"1.2.3", "1.2.3", 0, 0, 0},
+		{"1.2.3", "1.2.3-rc.1", 0, 0, 0},
+		{"1.2.3", "3.0.1", 2, 2, 2},
+		{"3.0.1", "1.2.3", 2, 2, 2},
+		{"1.10.0", "1.2.7", 0, 8, 7},
+	}
+
+	for _, test := range tests {
+		a, b := testVersion(t, test.a), testVersion(t, test.b)
+		major, minor, patch := a.Distance(b)
+		if major != test.major || minor != test.minor || patch != test.patch {
+			t.Errorf("Expected distance between %s and %s to be %d.%d.%d, got %d.%d.%d",
+				a, b, test.major, test.minor, test.patch, major, minor, patch)
+		}
+	}
+}
+
+func TestComparePrecedenceIgnoringPre(t *testing.T) {
+	tests := []struct {
+		a, b   string
+		expect int
+	}{
+		{"2.0.0-rc.1", "2.0.0", 0},
+		{"2.0.0", "2.0.0-alpha", 0},
+		{"2.0.0-alpha", "2.0.0-beta", 0},
+		{"2.0.1-rc.1", "2.0.0", 1},
+		{"2.0.0", "2.0.1", -1},
+	}
+
+	for _, test := range tests {
+		a, b := testVersion(t, test.a), testVersion(t, test.b)
+		if got := a.ComparePrecedenceIgnoringPre(b); got != test.expect {
+			t.Errorf("Expected %d comparing %s to %s, got %d", test.expect, a, b, got)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	a, b := testVersion(t, "1.2.3"), testVersion(t, "1.10.0")
+
+	if a.Compare(b) != -1 || b.Compare(a) != 1 || a.Compare(a) != 0 {
+		t.Errorf("Expected %s to be lower than %s", a, b)
+	}
+	if !a.Less(b) || b.Less(a) || a.Less(a) {
+		t.Errorf("Expected %s to be less than %s", a, b)
+	}
+}
+
+func TestCompareDetailed(t *testing.T) {
+	tests := []struct {
+		a, b   string
+		expect Comparison
+	}{
+		{"1.2.3", "1.2.3", Comparison{0, true, true, true}},
+		{"1.2.3+ci.1", "1.2.3+ci.2", Comparison{0, true, true, false}},
+		{"1.2.3-rc.1", "1.2.3", Comparison{-1, true, false, true}},
+		{"1.2.3-rc.1+ci.1", "1.2.3+ci.2", Comparison{-1, true, false, false}},
+		{"1.2.4", "1.2.3", Comparison{1, false, true, true}},
+		{"1.2.4+ci.1", "1.2.3+ci.1", Comparison{1, false, true, true}},
+		{"1.2.4-rc.1", "1.2.3-rc.1", Comparison{1, false, true, true}},
+		{"2.0.0-rc.1", "1.2.3-rc.2+ci.1", Comparison{1, false, false, false}},
+	}
+
+	for _, test := range tests {
+		got := testVersion(t, test.a).CompareDetailed(testVersion(t, test.b))
+		if got != test.expect {
+			t.Errorf("Expected %+v comparing %s with %s, got %+v", test.expect, test.a, test.b, got)
+		}
+	}
+}
+
+func TestComparator(t *testing.T) {
+	c := Comparator{PreOrder: []string{"dev", "alpha", "beta", "rc"}}
+
+	tests := []struct {
+		a, b   string
+		expect int
+	}{
+		{"1.2.3-dev.5", "1.2.3-rc.1", -1},
+		{"1.2.3-rc.1", "1.2.3-dev.5", 1},
+		{"1.2.3-dev.1", "1.2.3-alpha.1", -1},
+		{"1.2.3-rc.1", "1.2.3-rc.2", -1},
+		{"1.2.3-rc.1", "1.2.3", -1},
+		{"1.2.3-rc.1", "1.2.2", 1},
+		{"1.2.4-dev.1", "1.2.3-rc.9", 1},
+		{"1.2.3-preview", "1.2.3-rc.1", -1},
+		{"1.2.3-dev+ci.1", "1.2.3-dev+ci.2", 0},
+	}
+
+	for _, test := range tests {
+		a, b := testVersion(t, test.a), testVersion(t, test.b)
+		if got := c.Compare(a, b); got != test.expect {
+			t.Errorf("Expected %d comparing %s with %s, got %d", test.expect, test.a, test.b, got)
+		}
+	}
+
+	// An unlisted label between listed ones must not create a cycle.
+	cycle := Comparator{PreOrder: []string{"rc", "alpha"}}
+	alpha1, beta1, rc1 := testVersion(t, "1.2.3-alpha.1"), testVersion(t, "1.2.3-beta.1"), testVersion(t, "1.2.3-rc.1")
+	if cycle.Compare(beta1, rc1) != -1 || cycle.Compare(rc1, alpha1) != -1 || cycle.Compare(beta1, alpha1) != -1 {
+		t.Errorf("Expected beta < rc < alpha, got %d, %d and %d",
+			cycle.Compare(beta1, rc1), cycle.Compare(rc1, alpha1), cycle.Compare(beta1, alpha1))
+	}
+
+	// Without a policy, semver orders alpha below dev lexically.
+	dev, alpha := testVersion(t, "1.2.3-dev.1"), testVersion(t, "1.2.3-alpha.1")
+	if got := (Comparator{}).Compare(dev, alpha); got != 1 {
+		t.Errorf("Expected 1 without a policy, got %d", got)
+	}
+}
+
+func TestSort(t *testing.T) {
+	expect := []string{"1.0.0", "1.2.3-rc.1", "1.2.3", "2.0.0"}
+
+	vs := []Version{
+		testVersion(t, "2.0.0"),
+		testVersion(t, "1.2.3"),
+		testVersion(t, "1.0.0"),
+		testVersion(t, "1.2.3-rc.1"),
+	}
+	Sort(vs)
+
+	for i := range expect {
+		if vs[i].Semver() != expect[i] {
+			t.Errorf("Expected %s at %d, got %s", expect[i], i, vs[i])
+		}
+	}
+}
+
+// precedenceExamples lists the precedence examples from semver.org §11 in
+// ascending order.
+var precedenceExamples = []string{
+	"1.0.0-alpha",
+	"1.0.0-alpha.1",
+	"1.0.0-alpha.beta",
+	"1.0.0-beta",
+	"1.0.0-beta.2",
+	"1.0.0-beta.11",
+	"1.0.0-rc.1",
+	"1.0.0",
+	"2.0.0",
+	"2.1.0",
+	"2.1.1",
+}
+
+func TestPrecedenceExamples(t *testing.T) {
+	vs := testVersions(t, precedenceExamples...)
+
+	for i := range vs {
+		for j := range vs {
+			expect := 0
+			if i < j {
+				expect = -1
+			} else if i > j {
+				expect = 1
+			}
+			if got := vs[i].Compare(vs[j]); got != expect {
+				t.Errorf("Expected Compare(%s, %s) to be %d, got %d", vs[i], vs[j], expect, got)
+			}
+			if got := vs[i].Less(vs[j]); got != (i < j) {
+				t.Errorf("Expected Less(%s, %s) to be %t, got %t", vs[i], vs[j], i < j, got)
+			}
+		}
+	}
+}
+
+func TestPrecedenceExamplesSort(t *testing.T) {
+	vs := testVersions(t,
+		"1.0.0", "2.1.1", "1.0.0-beta.11", "1.0.0-alpha.beta", "1.0.0-rc.1", "2.0.0",
+		"1.0.0-alpha", "1.0.0-beta.2", "2.1.0", "1.0.0-beta", "1.0.0-alpha.1",
+	)
+	Sort(vs)
+
+	for i, expect := range precedenceExamples {
+		if vs[i].Semver() != expect {
+			t.Errorf("Expected %s at %d, got %s", expect, i, vs[i])
+		}
+	}
+}
+
+func TestSortTieBreak(t *testing.T) {
+	expect := []string{
+		"aaaaaaa Thu Feb 14 15:04:05 UTC 2019",
+		"bbbbbbb Wed Feb 13 15:04:05 UTC 2019",
+		"bbbbbbb Thu Feb 14 15:04:05 UTC 2019",
+		"ccccccc Thu Feb 14 15:04:05 UTC 2019",
+	}
+
+	var vs []Version
+	for _, s := range []string{expect[3], expect[2], expect[0], expect[1]} {
+		vconf := testConfig()
+		vconf.GitHash = s[:7]
+		vconf.TStamp = s[8:]
+		v, err := NewVersion(&vconf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		vs = append(vs, v)
+	}
+	Sort(vs)
+
+	for i := range expect {
+		got := vs[i].GitHash() + " " + vs[i].timestamp.Format(time.UnixDate)
+		if got != expect[i] {
+			t.Errorf("Expected %s at %d, got %s", expect[i], i, got)
+		}
+	}
+}
+
+// testVersions returns versions constructed from testConfig with the given
+// version strings.
+func testVersions(t *testing.T, ss ...string) []Version {
+	t.Helper()
+	var vs []Version
+	for _, s := range ss {
+		vs = append(vs, testVersion(t, s))
+	}
+	return vs
+}
+
+func TestSortStrings(t *testing.T) {
+	expect := []string{"1.0.0", "1.2.3-rc.1", "1.2.3", "1.2.3+ci.1", " 1.10.0", "2.0.0"}
+
+	ss := []string{"2.0.0", "1.2.3", " 1.10.0", "1.0.0", "1.2.3+ci.1", "1.2.3-rc.1"}
+	if err := SortStrings(ss); err != nil {
+		t.Fatal(err)
+	}
+	for i := range expect {
+		if ss[i] != expect[i] {
+			t.Errorf("Expected %q at %d, got %q", expect[i], i, ss[i])
+		}
+	}
+}
+
+func TestSortStringsInvalid(t *testing.T) {
+	expect := []string{"2.0.0", "1.2.3", "v1.0", "1.0.0"}
+
+	ss := append([]string(nil), expect...)
+	err := SortStrings(ss)
+	if !errors.Is(err, ErrInvalidVersion) {
+		t.Errorf("Expected ErrInvalidVersion, got %v", err)
+	}
+	for i := range expect {
+		if ss[i] != expect[i] {
+			t.Errorf("Expected the slice to be unmodified, got %q", ss)
+			break
+		}
+	}
+}
+
+func TestIsMonotonic(t *testing.T) {
+	tests := []struct {
+		versions []string
+		expect   bool
+		index    int
+	}{
+		{[]string{"1.0.0", "1.2.3-rc.1", "1.2.3", "2.0.0"}, true, -1},
+		{[]string{"1.0.0", "1.2.3", "1.1.0", "2.0.0"}, false, 2},
+		{[]string{"1.0.0", "1.2.3", "1.2.3+ci.2"}, false, 2},
+		{[]string{"1.0.0"}, true, -1},
+		{nil, true, -1},
+	}
+
+	for _, test := range tests {
+		ok, index := IsMonotonic(testVersions(t, test.versions...))
+		if ok != test.expect || index != test.index {
+			t.Errorf("Expected %t, %d for %v, got %t, %d", test.expect, test.index, test.versions, ok, index)
+		}
+	}
+}
+
+func TestRequireAtLeastVersion(t *testing.T) {
+	min := testVersion(t, "1.4.0")
+
+	for _, s := range []string{"1.4.0", "1.4.1", "2.0.0", "1.4.0+ci.1"} {
+		if err := testVersion(t, s).RequireAtLeastVersion(min); err != nil {
+			t.Errorf("Expected %s to satisfy %s, got %v", s, min, err)
+		}
+	}
+
+	tests := map[string]string{
+		"1.3.9":      "govee: version too old: 1.3.9 is older than the required minimum 1.4.0",
+		"1.4.0-rc.1": "govee: version too old: 1.4.0-rc.1 is older than the required minimum 1.4.0",
+	}
+	for s, expect := range tests {
+		err := testVersion(t, s).RequireAtLeastVersion(min)
+		if !errors.Is(err, ErrVersionTooOld) {
+			t.Errorf("Expected ErrVersionTooOld for %s, got %v", s, err)
+			continue
+		}
+		if err.Error() != expect {
+			t.Errorf("Expected %q, got %q", expect, err.Error())
+		}
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	expect := "1.2.0"
+
+	client := testVersions(t, "1.0.0", "1.2.0", "1.1.0", "2.0.0-rc.1")
+	server := testVersions(t, "1.1.0", "1.2.0", "1.0.0", "1.3.0")
+
+	v, ok := Negotiate(client, server)
+	if !ok {
+		t.Fatal("Expected a common version")
+	}
+	if v.Semver() != expect {
+		t.Errorf("Expected %s, got %s", expect, v.Semver())
+	}
+}
+
+func TestNegotiateSingle(t *testing.T) {
+	expect := "1.1.0"
+
+	client := testVersions(t, "1.0.0", "1.1.0")
+	server := testVersions(t, "1.1.0", "2.0.0")
+
+	v, ok := Negotiate(client, server)
+	if !ok {
+		t.Fatal("Expected a common version")
+	}
+	if v.Semver() != expect {
+		t.Errorf("Expected %s, got %s", expect, v.Semver())
+	}
+}
+
+func TestNegotiateDisjoint(t *testing.T) {
+	client := testVersions(t, "1.0.0", "1.1.0")
+	server := testVersions(t, "2.0.0", "2.1.0")
+
+	if v, ok := Negotiate(client, server); ok {
+		t.Errorf("Expected no common version, got %s", v)
+	}
+	if _, ok := Negotiate(nil, server); ok {
+		t.Error("Expected no common version for an empty client list")
+	}
+}
+
+func TestGroupByMajor(t *testing.T) {
+	expect := map[int][]string{
+		0: {"0.9.0"},
+		1: {"1.0.0-rc.1", "1.0.0", "1.2.3"},
+		2: {"2.0.0-alpha", "2.0.0-beta", "2.1.0"},
+	}
+
+	vs := testVersions(t, "2.1.0", "1.2.3", "0.9.0", "2.0.0-beta", "1.0.0", "2.0.0-alpha", "1.0.0-rc.1")
+	groups := GroupByMajor(vs)
+
+	if len(groups) != len(expect) {
+		t.Errorf("Expected %d groups, got %d", len(expect), len(groups))
+	}
+	for major, versions := range expect {
+		if len(groups[major]) != len(versions) {
+			t.Errorf("Expected %d versions in group %d, got %d", len(versions), major, len(groups[major]))
+			continue
+		}
+		for i := range versions {
+			if groups[major][i].Semver() != versions[i] {
+				t.Errorf("Expected %s at %d in group %d, got %s", versions[i], i, major, groups[major][i])
+			}
+		}
+	}
+}
+
+func TestLatestStable(t *testing.T) {
+	expect := "1.2.3"
+
+	vs := testVersions(t, "1.0.0", "2.0.0-rc.1", "1.2.3", "1.2.4-beta")
+	v, ok := LatestStable(vs)
+	if !ok {
+		t.Fatal("Expected a stable version")
+	}
+	if v.Semver() != expect {
+		t.Errorf("Expected %s, got %s", expect, v.Semver())
+	}
+}
+
+func TestLatestStableAllPreRelease(t *testing.T) {
+	vs := testVersions(t, "1.0.0-rc.1", "2.0.0-alpha")
+	if v, ok := LatestStable(vs); ok {
+		t.Errorf("Expected no stable version, got %s", v)
+	}
+}
+
+// testPlatformVersion returns a version constructed from testConfig with the
+// given version string, git hash and OS.
+func testPlatformVersion(t *testing.T, s, hash, os string) Version {
+	t.Helper()
+	vconf := testConfig()
+	vconf.VersionString = s
+	vconf.GitHash = hash
+	vconf.OS = os
+	v, err := NewVersion(&vconf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestSameSourceBuild(t *testing.T) {
+	vs := []Version{
+		testPlatformVersion(t, "1.2.3", "de6e4f2", "linux"),
+		testPlatformVersion(t, "1.2.3", "de6e4f2", "darwin"),
+		testPlatformVersion(t, "1.2.3", "de6e4f2", "windows"),
+	}
+
+	ok, mismatches := SameSourceBuild(vs)
+	if !ok || len(mismatches) != 0 {
+		t.Errorf("Expected a consistent set, got %v", mismatches)
+	}
+}
+
+func TestSameSourceBuildInconsistent(t *testing.T) {
+	expect := []string{"version", "git_hash"}
+
+	vs := []Version{
+		testPlatformVersion(t, "1.2.3", "de6e4f2", "linux"),
+		testPlatformVersion(t, "1.2.3", "a1b2c3d", "darwin"),
+		testPlatformVersion(t, "1.2.4", "de6e4f2", "windows"),
+	}
+
+	ok, mismatches := SameSourceBuild(vs)
+	if ok {
+		t.Error("Expected an inconsistent set")
+	}
+	if len(mismatches) != len(expect) {
+		t.Fatalf("Expected %v, got %v", expect, mismatches)
+	}
+	for i := range expect {
+		if mismatches[i] != expect[i] {
+			t.Errorf("Expected %s at %d, got %s", expect[i], i, mismatches[i])
+		}
+	}
+}
+
+func TestIsPreReleaseOf(t *testing.T) {
+	tests := []struct {
+		v, release string
+		expect     bool
+	}{
+		{"2.0.0-rc.2", "2.0.0", true},
+		{"2.0.0-alpha+ci.1", "2.0.0+ci.2", true},
+		{"2.0.0-rc.2", "2.0.1", false},
+		{"2.0.0-rc.1", "2.0.0-rc.2", false},
+		{"2.0.0", "2.0.0", false},
+	}
+
+	for _, test := range tests {
+		v, release := testVersion(t, test.v), testVersion(t, test.release)
+		if v.IsPreReleaseOf(release) != test.expect {
+			t.Errorf("Expected %s.IsPreReleaseOf(%s) to be %t", v, release, test.expect)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package govee
+
+import "strings"
+
+// Components holds the parsed parts of a semantic version. Pre and Build are
+// the dot-separated pre-release and build metadata, without their leading
+// "-" and "+".
+type Components struct {
+	Major, Minor, Patch int
+	Pre, Build          string
+}
+
+// Components returns the parsed parts of the version in a single value.
+func (v Version) Components() Components {
+	return Components{
+		Major: v.Major(),
+		Minor: v.Minor(),
+		Patch: v.Patch(),
+		Pre:   strings.Join(v.PreReleaseIDs(), "."),
+		Build: strings.Join(v.semver.Build, "."),
+	}
+}
+
+// PreReleaseIDs returns the pre-release identifiers in order, e.g.
+// ["rc", "1"] for 1.2.3-rc.1. It returns nil if there is no pre-release.
+func (v Version) PreReleaseIDs() []string {
+	var ids []string
+	for _, id := range v.semver.Pre {
+		ids = append(ids, id.String())
+	}
+	return ids
+}
@@ -0,0 +1,43 @@
+package govee
+
+import "testing"
+
+func TestComponents(t *testing.T) {
+	expect := Components{Major: 1, Minor: 2, Patch: 3, Pre: "rc.1.x-y", Build: "ci.1234.linux"}
+
+	v := testVersion(t, "1.2.3-rc.1.x-y+ci.1234.linux")
+	if v.Components() != expect {
+		t.Errorf("Expected %#v, got %#v", expect, v.Components())
+	}
+}
+
+func TestComponentsRelease(t *testing.T) {
+	expect := Components{Major: 4, Minor: 5, Patch: 6}
+
+	v := testVersion(t, "4.5.6")
+	if v.Components() != expect {
+		t.Errorf("Expected %#v, got %#v", expect, v.Components())
+	}
+}
+
+func TestPreReleaseIDs(t *testing.T) {
+	expect := []string{"2", "ga1b2c3d", "dirty"}
+
+	v := testVersion(t, "1.2.3-2.ga1b2c3d.dirty+ci.1")
+	ids := v.PreReleaseIDs()
+	if len(ids) != len(expect) {
+		t.Fatalf("Expected %v, got %v", expect, ids)
+	}
+	for i := range expect {
+		if ids[i] != expect[i] {
+			t.Errorf("Expected %s at %d, got %s", expect[i], i, ids[i])
+		}
+	}
+}
+
+func TestPreReleaseIDsEmpty(t *testing.T) {
+	v := testVersion(t, "1.2.3+ci.1")
+	if len(v.PreReleaseIDs()) != 0 {
+		t.Errorf("Expected no identifiers, got %v", v.PreReleaseIDs())
+	}
+}
@@ -0,0 +1,174 @@
+package govee
+
+import "time"
+
+// Merge returns a new VersionConfig with the non-empty fields of over
+// replacing those of c. Neither c nor over is modified.
+func (c *VersionConfig) Merge(over *VersionConfig) *VersionConfig {
+	m := VersionConfig{}
+	if c != nil {
+		m = *c
+	}
+	if over == nil {
+		return &m
+	}
+
+	mergeString(&m.VersionString, over.VersionString)
+	mergeString(&m.GitHash, over.GitHash)
+	mergeString(&m.GitBranch, over.GitBranch)
+	mergeString(&m.GitUser, over.GitUser)
+	mergeString(&m.GitTag, over.GitTag)
+	mergeString(&m.OS, over.OS)
+	mergeString(&m.Arch, over.Arch)
+	mergeString(&m.Compiler, over.Compiler)
+	mergeString(&m.Release, over.Release)
+	mergeString(&m.TStamp, over.TStamp)
+	mergeString(&m.CIProvider, over.CIProvider)
+	mergeString(&m.CIRunID, over.CIRunID)
+	mergeString(&m.CIRunURL, over.CIRunURL)
+	if over.TimestampLocation != nil {
+		m.TimestampLocation = over.TimestampLocation
+	}
+	if over.TimestampTolerant {
+		m.TimestampTolerant = true
+	}
+	if over.TimestampParser != nil {
+		m.TimestampParser = over.TimestampParser
+	}
+	if over.VersionTolerant {
+		m.VersionTolerant = true
+	}
+	if over.Warn0x {
+		m.Warn0x = true
+	}
+	if over.BranchReleases != nil {
+		m.BranchReleases = over.BranchReleases
+	}
+	if over.ReleaseCaseSensitive {
+		m.ReleaseCaseSensitive = true
+	}
+	mergeString(&m.MinGoVersion, over.MinGoVersion)
+	if over.NoVCS {
+		m.NoVCS = true
+	}
+	if over.BranchUnknown {
+		m.BranchUnknown = true
+	}
+	if over.Validators != nil {
+		m.Validators = over.Validators
+	}
+	return &m
+}
+
+// Config reconstructs a VersionConfig from the version, such that passing it
+// to NewVersion produces an equal version. The timestamp is formatted as
+// RFC 3339 with nanoseconds. A version without a timestamp, or with one that
+// was tolerated as unparseable, gets TimestampTolerant, and a defaulted
+// version gets VersionTolerant. TimestampLocation, TimestampParser and
+// Validators only affect construction and aren't recovered.
+func (v Version) Config() VersionConfig {
+	c := VersionConfig{
+		GitHash:              v.githash,
+		GitBranch:            v.gitbranch,
+		GitUser:              v.gituser,
+		GitTag:               v.gittag,
+		OS:                   v.os,
+		Arch:                 v.arch,
+		Compiler:             v.compiler,
+		Release:              v.release,
+		CIProvider:           v.ciprovider,
+		CIRunID:              v.cirunid,
+		CIRunURL:             v.cirunurl,
+		Warn0x:               v.warn0x,
+		ReleaseCaseSensitive: v.relcase,
+		BranchReleases:       copyBranchReleases(v.branchrel),
+		MinGoVersion:         v.mingo,
+		NoVCS:                v.novcs,
+		BranchUnknown:        v.nobranch,
+	}
+	if v.noversion {
+		c.VersionTolerant = true
+	} else {
+		c.VersionString = v.semver.String()
+	}
+	switch {
+	case v.badtstamp != "":
+		c.TStamp = v.badtstamp
+		c.TimestampTolerant = true
+	case v.timestamp.IsZero():
+		c.TimestampTolerant = true
+	default:
+		c.TStamp = v.timestamp.Format(time.RFC3339Nano)
+	}
+	return c
+}
+
+// mergeString sets dst to src if src is not empty.
+func mergeString(dst *string, src string) {
+	if src != "" {
+		*dst = src
+	}
+}
+
+// Equal reports whether c and other hold the same configuration. Two nil
+// configs are equal; a nil config is not equal to a non-nil one. Functions
+// can't be compared, so TimestampParser only needs to be set in both or
+// neither config, and Validators only need to be of the same length.
+func (c *VersionConfig) Equal(other *VersionConfig) bool {
+	if c == nil || other == nil {
+		return c == other
+	}
+	return c.VersionString == other.VersionString &&
+		c.GitHash == other.GitHash &&
+		c.GitBranch == other.GitBranch &&
+		c.GitUser == other.GitUser &&
+		c.GitTag == other.GitTag &&
+		c.OS == other.OS &&
+		c.Arch == other.Arch &&
+		c.Compiler == other.Compiler &&
+		c.Release == other.Release &&
+		c.TStamp == other.TStamp &&
+		c.CIProvider == other.CIProvider &&
+		c.CIRunID == other.CIRunID &&
+		c.CIRunURL == other.CIRunURL &&
+		equalLocation(c.TimestampLocation, other.TimestampLocation) &&
+		c.TimestampTolerant == other.TimestampTolerant &&
+		(c.TimestampParser == nil) == (other.TimestampParser == nil) &&
+		c.VersionTolerant == other.VersionTolerant &&
+		c.Warn0x == other.Warn0x &&
+		equalBranchReleases(c.BranchReleases, other.BranchReleases) &&
+		c.ReleaseCaseSensitive == other.ReleaseCaseSensitive &&
+		c.MinGoVersion == other.MinGoVersion &&
+		c.NoVCS == other.NoVCS &&
+		c.BranchUnknown == other.BranchUnknown &&
+		len(c.Validators) == len(other.Validators)
+}
+
+// equalLocation reports whether a and b are both unset or name the same
+// location. A nil *time.Location reports itself as UTC, so nil is checked
+// explicitly.
+func equalLocation(a, b *time.Location) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.String() == b.String()
+}
+
+// equalBranchReleases reports whether a and b hold the same pairings.
+func equalBranchReleases(a, b map[string][]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for branch, releases := range a {
+		other, ok := b[branch]
+		if !ok || len(releases) != len(other) {
+			return false
+		}
+		for i := range releases {
+			if releases[i] != other[i] {
+				return false
+			}
+		}
+	}
+	return true
+}
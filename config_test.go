@@ -0,0 +1,226 @@
+package govee
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMergeFull(t *testing.T) {
+	base := testConfig()
+	over := VersionConfig{
+		VersionString: "2.0.0",
+		GitHash:       "fedcba0987654321",
+		GitBranch:     "master",
+		GitUser:       "John Doe",
+		OS:            "darwin",
+		Arch:          "arm64",
+		Compiler:      "go1.12",
+		Release:       "test",
+		TStamp:        "Fri Feb 15 15:04:05 UTC 2019",
+		MinGoVersion:  "go1.11",
+	}
+
+	m := base.Merge(&over)
+	if !m.Equal(&over) {
+		t.Errorf("Expected %#v, got %#v", over, *m)
+	}
+}
+
+func TestMergePartial(t *testing.T) {
+	base := testConfig()
+	over := VersionConfig{
+		GitHash:   "fedcba0987654321",
+		GitBranch: "master",
+	}
+
+	m := base.Merge(&over)
+	if m.GitHash != over.GitHash {
+		t.Errorf("Expected %s, got %s", over.GitHash, m.GitHash)
+	}
+	if m.GitBranch != over.GitBranch {
+		t.Errorf("Expected %s, got %s", over.GitBranch, m.GitBranch)
+	}
+	if m.OS != base.OS {
+		t.Errorf("Expected %s, got %s", base.OS, m.OS)
+	}
+	if base.GitHash != testConfig().GitHash {
+		t.Errorf("Expected base to be unchanged, got %s", base.GitHash)
+	}
+}
+
+func TestMergeEmpty(t *testing.T) {
+	base := testConfig()
+
+	m := base.Merge(&VersionConfig{})
+	if !m.Equal(&base) {
+		t.Errorf("Expected %#v, got %#v", base, *m)
+	}
+	if m == &base {
+		t.Error("Expected a new config, got the receiver")
+	}
+}
+
+func TestEqual(t *testing.T) {
+	a := testConfig()
+	b := testConfig()
+
+	if !a.Equal(&b) {
+		t.Errorf("Expected %#v to equal %#v", a, b)
+	}
+}
+
+func TestEqualDifferentField(t *testing.T) {
+	a := testConfig()
+	b := testConfig()
+	b.GitBranch = "master"
+
+	if a.Equal(&b) {
+		t.Errorf("Expected %#v not to equal %#v", a, b)
+	}
+
+	c := testConfig()
+	c.TimestampLocation = time.UTC
+	if a.Equal(&c) {
+		t.Errorf("Expected %#v not to equal %#v", a, c)
+	}
+}
+
+func TestEqualNil(t *testing.T) {
+	var a, b *VersionConfig
+	c := testConfig()
+
+	if !a.Equal(b) {
+		t.Error("Expected two nil configs to be equal")
+	}
+	if a.Equal(&c) {
+		t.Error("Expected a nil config not to equal a non-nil config")
+	}
+	if c.Equal(nil) {
+		t.Error("Expected a non-nil config not to equal a nil config")
+	}
+}
+
+func TestEqualBranchReleases(t *testing.T) {
+	a := testConfig()
+	a.BranchReleases = map[string][]string{"master": {"prod"}}
+	b := testConfig()
+	b.BranchReleases = map[string][]string{"master": {"prod"}}
+
+	if !a.Equal(&b) {
+		t.Errorf("Expected %#v to equal %#v", a, b)
+	}
+
+	b.BranchReleases["master"] = []string{"test"}
+	if a.Equal(&b) {
+		t.Errorf("Expected %#v not to equal %#v", a, b)
+	}
+}
+
+func TestConfigRoundTrip(t *testing.T) {
+	tolerant := testConfig()
+	tolerant.VersionString = ""
+	tolerant.VersionTolerant = true
+	tolerant.TStamp = "yesterday"
+	tolerant.TimestampTolerant = true
+
+	rules := testConfig()
+	rules.GitTag = "v1.2.3"
+	rules.CIProvider = "github-actions"
+	rules.CIRunID = "1234"
+	rules.Warn0x = true
+	rules.BranchReleases = map[string][]string{"testing": {"test"}}
+	rules.MinGoVersion = "go1.20"
+	rules.TimestampLocation = time.FixedZone("SAST", 2*60*60)
+
+	for name, vconf := range map[string]VersionConfig{
+		"full":     testConfig(),
+		"tolerant": tolerant,
+		"rules":    rules,
+	} {
+		v, err := NewVersion(&vconf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c := v.Config()
+		got, err := NewVersion(&c)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if !got.EqualFull(v) {
+			t.Errorf("%s: Expected %#v, got %#v", name, v, got)
+		}
+		if len(got.Warnings()) != len(v.Warnings()) {
+			t.Errorf("%s: Expected %v, got %v", name, v.Warnings(), got.Warnings())
+		}
+	}
+}
+
+func TestConfigWithoutTimestamp(t *testing.T) {
+	v, err := ParseVersionString("1.2.3-rc.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := v.Config()
+	if c.VersionString != "1.2.3-rc.1" || c.TStamp != "" {
+		t.Errorf("Expected 1.2.3-rc.1 without a timestamp, got %#v", c)
+	}
+	got, err := NewVersion(&c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.EqualFull(v) {
+		t.Errorf("Expected %#v, got %#v", v, got)
+	}
+}
+
+func TestConfigNoVCS(t *testing.T) {
+	noVCS, err := FromGoVersionM("app: go1.22.1\n\tmod\texample.com/app\tv1.2.3\t\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	withVCS, err := FromGoVersionM(goVersionM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	oci, err := FromOCILabels(map[string]string{
+		OCILabelVersion:  "1.2.3",
+		OCILabelRevision: "de6e4f2f6afbe97e9e96c12efef66fb4b65a0a3d",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, v := range map[string]Version{"no-vcs": noVCS, "vcs": withVCS, "oci": oci} {
+		c := v.Config()
+		got, err := NewVersion(&c)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if !got.EqualFull(v) {
+			t.Errorf("%s: Expected %v, got %v", name, v.Warnings(), got.Warnings())
+		}
+	}
+}
+
+func TestMergeValidators(t *testing.T) {
+	base := testConfig()
+	base.Validators = []func(Version) error{func(Version) error { return nil }}
+	over := VersionConfig{
+		Validators: []func(Version) error{
+			func(Version) error { return nil },
+			func(Version) error { return nil },
+		},
+	}
+
+	if m := base.Merge(&VersionConfig{}); len(m.Validators) != 1 {
+		t.Errorf("Expected the validators to be kept, got %d", len(m.Validators))
+	}
+	m := base.Merge(&over)
+	if len(m.Validators) != 2 {
+		t.Errorf("Expected the validators to be replaced, got %d", len(m.Validators))
+	}
+	if m.Equal(&base) {
+		t.Error("Expected configs with different validators not to be equal")
+	}
+}
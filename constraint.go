@@ -0,0 +1,190 @@
+package govee
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/blang/semver"
+)
+
+// SatisfiesAny reports whether the version satisfies at least one of the
+// given constraints. Each constraint is a range such as ">=1.2.0 <1.5.0" and
+// may itself combine ranges with "||". An error is returned if a constraint
+// cannot be parsed.
+func (v Version) SatisfiesAny(constraints ...string) (bool, error) {
+	for _, c := range constraints {
+		r, err := semver.ParseRange(c)
+		if err != nil {
+			return false, fmt.Errorf("govee: invalid constraint %q: %s", c, err)
+		}
+		if r(v.semver) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// SatisfiesAll reports whether the version satisfies every one of the given
+// constraints. If it does not, the returned error names the first constraint
+// that failed. An error is also returned if a constraint cannot be parsed.
+func (v Version) SatisfiesAll(constraints ...string) (bool, error) {
+	for _, c := range constraints {
+		r, err := semver.ParseRange(c)
+		if err != nil {
+			return false, fmt.Errorf("govee: invalid constraint %q: %s", c, err)
+		}
+		if !r(v.semver) {
+			return false, fmt.Errorf("govee: version %s does not satisfy constraint %q", v, c)
+		}
+	}
+	return true, nil
+}
+
+// IsBanned reports whether the version matches any entry of a kill-switch
+// list. Each entry is either an exact version such as "1.2.3" or a range
+// such as ">=1.4.0 <1.4.3"; build metadata is ignored when matching. Every
+// entry is parsed before any is matched, so an invalid entry is reported as an
+// error rather than silently skipped.
+func (v Version) IsBanned(banned []string) (bool, error) {
+	ranges := make([]semver.Range, 0, len(banned))
+	for _, b := range banned {
+		r, err := semver.ParseRange(strings.TrimSpace(b))
+		if err != nil {
+			return false, fmt.Errorf("govee: invalid banned version %q: %s", b, err)
+		}
+		ranges = append(ranges, r)
+	}
+	for _, r := range ranges {
+		if r(v.semver) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Constraint is a parsed version range that can be checked against many
+// versions without being parsed again.
+type Constraint struct {
+	s string
+	r semver.Range
+}
+
+// NewConstraint parses a version range such as ">=1.2.0 <1.5.0 || >=2.0.0".
+func NewConstraint(s string) (*Constraint, error) {
+	r, err := semver.ParseRange(s)
+	if err != nil {
+		return nil, fmt.Errorf("govee: invalid constraint %q: %s", s, err)
+	}
+	return &Constraint{s: s, r: r}, nil
+}
+
+// Check reports whether v satisfies the constraint.
+func (c *Constraint) Check(v Version) bool {
+	return c.r(v.semver)
+}
+
+// String returns the constraint as it was given to NewConstraint.
+func (c *Constraint) String() string {
+	return c.s
+}
+
+// Explain reports whether the version satisfies the constraint, together with
+// a human-readable explanation such as "1.5.0 is not < 1.5.0". If the
+// constraint has several alternatives separated by "||", the explanation of a
+// failure lists the first failing comparison of each. An error is returned
+// only if the constraint can't be parsed.
+func (v Version) Explain(constraint string) (bool, string, error) {
+	r, err := semver.ParseRange(constraint)
+	if err != nil {
+		return false, "", fmt.Errorf("govee: invalid constraint %q: %s", constraint, err)
+	}
+	if r(v.semver) {
+		return true, fmt.Sprintf("%s satisfies %s", v, strings.TrimSpace(constraint)), nil
+	}
+
+	var reasons []string
+	for _, alt := range strings.Split(constraint, "||") {
+		for _, cmp := range splitComparators(alt) {
+			r, err := semver.ParseRange(cmp)
+			if err != nil {
+				return false, "", fmt.Errorf("govee: invalid constraint %q: %s", constraint, err)
+			}
+			if !r(v.semver) {
+				reasons = append(reasons, describeFailure(v, cmp))
+				break
+			}
+		}
+	}
+	return false, strings.Join(reasons, " and "), nil
+}
+
+// splitComparators splits a range without "||" into its comparators, joining
+// operators separated from their version by spaces, as in ">= 1.2.0".
+func splitComparators(s string) []string {
+	var cmps []string
+	pending := ""
+	for _, f := range strings.Fields(s) {
+		if strings.Trim(f, "<>=!") == "" {
+			pending += f
+			continue
+		}
+		cmps = append(cmps, pending+f)
+		pending = ""
+	}
+	return cmps
+}
+
+// describeFailure explains why v fails a comparator, such as "1.5.0 is not
+// < 1.5.0" for "<1.5.0". Equality comparators are described by the version
+// alone, and exclusions, written with "!" or "!=", as "1.6.0 is excluded by
+// != 1.6.0".
+func describeFailure(v Version, cmp string) string {
+	ver := strings.TrimLeft(cmp, "<>=!")
+	switch op := cmp[:len(cmp)-len(ver)]; op {
+	case "", "=", "==":
+		return fmt.Sprintf("%s is not %s", v, ver)
+	case "!", "!=":
+		return fmt.Sprintf("%s is excluded by != %s", v, ver)
+	default:
+		return fmt.Sprintf("%s is not %s %s", v, op, ver)
+	}
+}
+
+// CompatPolicy selects the rules APICompatible uses.
+type CompatPolicy int
+
+// Compatibility policies, named after the npm range operators.
+const (
+	// CompatCaret (^) accepts any later version with the same major
+	// number. Below 1.0.0 the minor number must match too, and below 0.1.0
+	// the patch number as well.
+	CompatCaret CompatPolicy = iota
+	// CompatTilde (~) accepts any later version with the same major and
+	// minor numbers.
+	CompatTilde
+)
+
+// APICompatible reports whether provided satisfies a requirement for
+// required under policy: provided must have at least the precedence of
+// required, and share the components that policy fixes. An unknown policy is
+// never compatible.
+func APICompatible(required, provided Version, policy CompatPolicy) bool {
+	if provided.Less(required) {
+		return false
+	}
+
+	r, p := required.semver, provided.semver
+	switch policy {
+	case CompatCaret:
+		switch {
+		case r.Major > 0:
+			return p.Major == r.Major
+		case r.Minor > 0:
+			return p.Major == 0 && p.Minor == r.Minor
+		}
+		return p.Major == 0 && p.Minor == 0 && p.Patch == r.Patch
+	case CompatTilde:
+		return p.Major == r.Major && p.Minor == r.Minor
+	}
+	return false
+}
@@ -0,0 +1,256 @@
+package govee
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSatisfiesAny(t *testing.T) {
+	vconf := testConfig()
+	vconf.VersionString = "2.1.0"
+	v, err := NewVersion(&vconf)
+	if err != nil {
+		t.Error(err)
+	}
+
+	ok, err := v.SatisfiesAny(">=1.2.0 <1.5.0 || >=2.0.0")
+	if err != nil {
+		t.Error(err)
+	}
+	if !ok {
+		t.Errorf("Expected %s to satisfy the OR range", v)
+	}
+
+	ok, err = v.SatisfiesAny("<1.0.0", ">=2.0.0 <3.0.0")
+	if err != nil {
+		t.Error(err)
+	}
+	if !ok {
+		t.Errorf("Expected %s to satisfy one of the constraints", v)
+	}
+
+	ok, err = v.SatisfiesAny("<1.0.0", ">=3.0.0")
+	if err != nil {
+		t.Error(err)
+	}
+	if ok {
+		t.Errorf("Expected %s not to satisfy any constraint", v)
+	}
+}
+
+func TestSatisfiesAll(t *testing.T) {
+	vconf := testConfig()
+	v, err := NewVersion(&vconf)
+	if err != nil {
+		t.Error(err)
+	}
+
+	ok, err := v.SatisfiesAll(">=1.0.0", "<2.0.0")
+	if err != nil {
+		t.Error(err)
+	}
+	if !ok {
+		t.Errorf("Expected %s to satisfy all constraints", v)
+	}
+}
+
+func TestSatisfiesAllFailure(t *testing.T) {
+	expect := `"<1.2.0"`
+
+	vconf := testConfig()
+	v, err := NewVersion(&vconf)
+	if err != nil {
+		t.Error(err)
+	}
+
+	ok, err := v.SatisfiesAll(">=1.0.0", "<1.2.0", ">=2.0.0")
+	if ok {
+		t.Errorf("Expected %s not to satisfy all constraints", v)
+	}
+	if err == nil || !strings.Contains(err.Error(), expect) {
+		t.Errorf("Expected an error naming %s, got %v", expect, err)
+	}
+}
+
+func TestSatisfiesInvalidConstraint(t *testing.T) {
+	vconf := testConfig()
+	v, err := NewVersion(&vconf)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if _, err := v.SatisfiesAny("not a range"); err == nil {
+		t.Error("Expected an error for an invalid constraint")
+	}
+	if _, err := v.SatisfiesAll("not a range"); err == nil {
+		t.Error("Expected an error for an invalid constraint")
+	}
+}
+
+func TestIsBanned(t *testing.T) {
+	banned := []string{"1.2.3", ">=1.4.0 <1.4.3", "2.0.0-rc.1"}
+
+	tests := map[string]bool{
+		"1.2.3":      true,
+		"1.2.3+ci.7": true,
+		"1.4.0":      true,
+		"1.4.2":      true,
+		"2.0.0-rc.1": true,
+		"1.2.4":      false,
+		"1.4.3":      false,
+		"2.0.0":      false,
+	}
+
+	for version, expect := range tests {
+		ok, err := testVersion(t, version).IsBanned(banned)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok != expect {
+			t.Errorf("Expected IsBanned for %s to be %t", version, expect)
+		}
+	}
+
+	ok, err := testVersion(t, "1.2.3").IsBanned(nil)
+	if err != nil || ok {
+		t.Errorf("Expected an empty list to ban nothing, got %t, %v", ok, err)
+	}
+}
+
+func TestIsBannedInvalid(t *testing.T) {
+	ok, err := testVersion(t, "1.2.3").IsBanned([]string{"1.2.3", "not-a-version"})
+	if err == nil {
+		t.Fatal("Expected an error for an invalid banned version")
+	}
+	if ok {
+		t.Error("Expected false alongside the error")
+	}
+	if !strings.Contains(err.Error(), "not-a-version") {
+		t.Errorf("Expected the error to name the entry, got %v", err)
+	}
+}
+
+func TestConstraint(t *testing.T) {
+	tests := map[string]bool{
+		"1.0.0": false,
+		"1.2.0": true,
+		"1.4.9": true,
+		"1.5.0": false,
+		"2.3.0": true,
+	}
+
+	c, err := NewConstraint(">=1.2.0 <1.5.0 || >=2.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for s, expect := range tests {
+		if c.Check(testVersion(t, s)) != expect {
+			t.Errorf("Expected Check(%s) against %s to be %t", s, c, expect)
+		}
+	}
+
+	if _, err := NewConstraint("not a range"); err == nil {
+		t.Error("Expected an error for an invalid constraint")
+	}
+}
+
+func BenchmarkConstraintCheck(b *testing.B) {
+	v, err := ParseVersionString("1.3.0")
+	if err != nil {
+		b.Fatal(err)
+	}
+	c, err := NewConstraint(">=1.2.0 <1.5.0 || >=2.0.0")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Check(v)
+	}
+}
+
+func BenchmarkConstraintReparse(b *testing.B) {
+	v, err := ParseVersionString("1.3.0")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.SatisfiesAny(">=1.2.0 <1.5.0 || >=2.0.0")
+	}
+}
+
+func TestExplain(t *testing.T) {
+	tests := []struct {
+		version, constraint string
+		ok                  bool
+		expect              string
+	}{
+		{"1.2.3", ">=1.0.0 <2.0.0", true, "1.2.3 satisfies >=1.0.0 <2.0.0"},
+		{"1.5.0", ">=1.0.0 <1.5.0", false, "1.5.0 is not < 1.5.0"},
+		{"0.9.0", ">= 1.0.0 <1.5.0", false, "0.9.0 is not >= 1.0.0"},
+		{"1.6.0", "1.5.0", false, "1.6.0 is not 1.5.0"},
+		{"1.6.0", "!=1.6.0", false, "1.6.0 is excluded by != 1.6.0"},
+		{"1.6.0", "!1.6.0", false, "1.6.0 is excluded by != 1.6.0"},
+		{"1.6.0", "<1.5.0 || >=2.0.0", false, "1.6.0 is not < 1.5.0 and 1.6.0 is not >= 2.0.0"},
+	}
+
+	for _, test := range tests {
+		v := testVersion(t, test.version)
+		ok, explanation, err := v.Explain(test.constraint)
+		if err != nil {
+			t.Errorf("Expected no error for %q, got %s", test.constraint, err)
+			continue
+		}
+		if ok != test.ok {
+			t.Errorf("Expected %s satisfying %q to be %t", v, test.constraint, test.ok)
+		}
+		if explanation != test.expect {
+			t.Errorf("Expected %q, got %q", test.expect, explanation)
+		}
+	}
+}
+
+func TestExplainInvalid(t *testing.T) {
+	v := testVersion(t, "1.2.3")
+
+	ok, explanation, err := v.Explain(">=1.0.0 <<2")
+	if err == nil {
+		t.Error("Expected an error for an invalid constraint")
+	}
+	if ok || explanation != "" {
+		t.Errorf("Expected no result for an invalid constraint, got %t %q", ok, explanation)
+	}
+}
+
+func TestAPICompatible(t *testing.T) {
+	tests := []struct {
+		required, provided string
+		policy             CompatPolicy
+		expect             bool
+	}{
+		{"1.2.3", "1.2.3", CompatCaret, true},
+		{"1.2.3", "1.9.0", CompatCaret, true},
+		{"1.2.3", "2.0.0", CompatCaret, false},
+		{"1.2.3", "1.2.2", CompatCaret, false},
+		{"1.2.3", "1.2.3-rc.1", CompatCaret, false},
+		{"0.2.3", "0.2.9", CompatCaret, true},
+		{"0.2.3", "0.3.0", CompatCaret, false},
+		{"0.0.3", "0.0.3+ci.1", CompatCaret, true},
+		{"0.0.3", "0.0.4", CompatCaret, false},
+		{"1.2.3", "1.2.9", CompatTilde, true},
+		{"1.2.3", "1.3.0", CompatTilde, false},
+		{"1.2.3", "1.2.0", CompatTilde, false},
+		{"0.2.3", "0.2.4", CompatTilde, true},
+		{"1.2.3", "1.2.3", CompatPolicy(-1), false},
+	}
+
+	for _, test := range tests {
+		required, provided := testVersion(t, test.required), testVersion(t, test.provided)
+		if got := APICompatible(required, provided, test.policy); got != test.expect {
+			t.Errorf("Expected %s to be compatible with %s under policy %d: %t", test.provided, test.required, test.policy, test.expect)
+		}
+	}
+}
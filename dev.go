@@ -0,0 +1,26 @@
+package govee
+
+import (
+	"runtime"
+
+	"github.com/blang/semver"
+)
+
+// Dev returns a placeholder version for builds made without version
+// information, e.g. with go run. It is version 0.0.0-dev with release "dev",
+// the OS, architecture and compiler of the running program, and the current
+// time as its timestamp.
+func Dev() Version {
+	v := Version{
+		semver: semver.Version{
+			Pre: []semver.PRVersion{{VersionStr: "dev"}},
+		},
+		os:        runtime.GOOS,
+		arch:      runtime.GOARCH,
+		compiler:  runtime.Version(),
+		release:   "dev",
+		timestamp: now(),
+	}
+	v.setWarnings()
+	return v
+}
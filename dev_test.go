@@ -0,0 +1,42 @@
+package govee
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestDev(t *testing.T) {
+	expect := "0.0.0-dev"
+	expectWarning := "This version is tagged as release \"dev\". Please don't use in production."
+
+	n := time.Date(2019, time.February, 14, 15, 4, 5, 0, time.UTC)
+	setNow(t, n)
+
+	v := Dev()
+	if v.Semver() != expect {
+		t.Errorf("Expected %s, got %s", expect, v.Semver())
+	}
+	if v.Release() != "dev" {
+		t.Errorf("Expected dev, got %s", v.Release())
+	}
+	if v.OS() != runtime.GOOS || v.Arch() != runtime.GOARCH {
+		t.Errorf("Expected %s/%s, got %s/%s", runtime.GOOS, runtime.GOARCH, v.OS(), v.Arch())
+	}
+	if v.Compiler() != runtime.Version() {
+		t.Errorf("Expected %s, got %s", runtime.Version(), v.Compiler())
+	}
+	if !v.timestamp.Equal(n) {
+		t.Errorf("Expected %s, got %s", n, v.timestamp)
+	}
+
+	found := false
+	for _, w := range v.Warnings() {
+		if w == expectWarning {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected the warning %q, got %v", expectWarning, v.Warnings())
+	}
+}
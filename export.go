@@ -0,0 +1,342 @@
+package govee
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"log/slog"
+	"net/url"
+	"strings"
+)
+
+// Range calls fn for each metadata field of the version, in the following
+// order: version, git_hash, git_branch, git_user, git_tag, os, arch,
+// compiler, release and timestamp. Empty fields are included, and an unset
+// timestamp is empty rather than the zero time. These are followed by
+// ci_provider, ci_run_id and ci_run_url, each only if set.
+func (v Version) Range(fn func(key, value string)) {
+	fn("version", v.Semver())
+	fn("git_hash", v.GitHash())
+	fn("git_branch", v.GitBranch())
+	fn("git_user", v.GitUser())
+	fn("git_tag", v.GitTag())
+	fn("os", v.OS())
+	fn("arch", v.Arch())
+	fn("compiler", v.Compiler())
+	fn("release", v.Release())
+	fn("timestamp", v.tstamp())
+	if v.ciprovider != "" {
+		fn("ci_provider", v.ciprovider)
+	}
+	if v.cirunid != "" {
+		fn("ci_run_id", v.cirunid)
+	}
+	if v.cirunurl != "" {
+		fn("ci_run_url", v.cirunurl)
+	}
+}
+
+// DotEnv returns the version information as newline-separated KEY=value
+// lines, suitable for sourcing from a shell. Keys are the upper-cased Range
+// keys, prefixed with prefix and an underscore unless prefix is empty. Values
+// containing characters other than letters, digits and ._-+/:@ are
+// double-quoted, with carriage returns and newlines escaped as \r and \n so
+// that every variable stays on one line. $GITHUB_ENV keeps the quotes as part
+// of the value, so only values that need no quoting pass through it intact.
+func (v Version) DotEnv(prefix string) string {
+	if prefix != "" {
+		prefix += "_"
+	}
+
+	var b strings.Builder
+	v.Range(func(key, value string) {
+		b.WriteString(prefix + strings.ToUpper(key) + "=" + dotEnvQuote(value) + "\n")
+	})
+	return b.String()
+}
+
+// dotEnvQuote double-quotes s if it contains characters that a shell would
+// interpret, escaping the characters that remain special inside quotes and
+// line breaks.
+func dotEnvQuote(s string) string {
+	safe := true
+	for _, r := range s {
+		if !isDotEnvSafe(r) {
+			safe = false
+			break
+		}
+	}
+	if safe {
+		return s
+	}
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`, "`", "\\`", "\r", `\r`, "\n", `\n`)
+	return `"` + r.Replace(s) + `"`
+}
+
+func isDotEnvSafe(r rune) bool {
+	switch {
+	case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		return true
+	}
+	return strings.ContainsRune("._-+/:@", r)
+}
+
+// DockerTag returns the version as a valid Docker image tag. The "+" that
+// separates build metadata is not allowed in tags and is replaced with "_";
+// since "_" never appears in a semver string, the original can be recovered
+// by reversing the replacement. Tags are truncated to Docker's limit of 128
+// characters.
+func (v Version) DockerTag() string {
+	tag := strings.Replace(v.Semver(), "+", "_", -1)
+	if len(tag) > 128 {
+		tag = tag[:128]
+	}
+	return tag
+}
+
+// UserAgent returns an HTTP user agent string such as
+// "myapp/1.2.3 (linux/amd64)". The app name is omitted if empty, and the
+// platform comment contains only the OS and architecture that are set, or is
+// omitted if neither is.
+func (v Version) UserAgent(appName string) string {
+	ua := v.Semver()
+	if appName != "" {
+		ua = appName + "/" + ua
+	}
+
+	var platform []string
+	if v.os != "" {
+		platform = append(platform, v.os)
+	}
+	if v.arch != "" {
+		platform = append(platform, v.arch)
+	}
+	if len(platform) > 0 {
+		ua += " (" + strings.Join(platform, "/") + ")"
+	}
+	return ua
+}
+
+// Slug returns a URL-safe anchor for the version, such as v1-2-3 for 1.2.3,
+// for linking to release notes. The version is lower-cased and dots and the
+// build metadata "+" are replaced with hyphens.
+func (v Version) Slug() string {
+	r := strings.NewReplacer(".", "-", "+", "-")
+	return "v" + r.Replace(strings.ToLower(v.Semver()))
+}
+
+// Provenance returns the build provenance facts of the version, for use in
+// supply-chain attestations: git_hash, git_branch, git_user, git_tag and
+// timestamp. The git_tag key is only present if the tag is set, and the
+// timestamp is empty if it is unset.
+func (v Version) Provenance() map[string]string {
+	p := map[string]string{
+		"git_hash":   v.GitHash(),
+		"git_branch": v.GitBranch(),
+		"git_user":   v.GitUser(),
+		"timestamp":  v.tstamp(),
+	}
+	if v.gittag != "" {
+		p["git_tag"] = v.gittag
+	}
+	return p
+}
+
+// tstamp returns the timestamp in RFC 3339 format, or "" if it is not set.
+func (v Version) tstamp() string {
+	if v.timestamp.IsZero() {
+		return ""
+	}
+	return v.TStamp()
+}
+
+// versionJSON is the JSON form of a Version.
+type versionJSON struct {
+	Version    string   `json:"version"`
+	GitHash    string   `json:"git_hash"`
+	GitBranch  string   `json:"git_branch"`
+	GitUser    string   `json:"git_user"`
+	GitTag     string   `json:"git_tag,omitempty"`
+	OS         string   `json:"os"`
+	Arch       string   `json:"arch"`
+	Compiler   string   `json:"compiler"`
+	Release    string   `json:"release"`
+	Timestamp  string   `json:"timestamp,omitempty"`
+	CIProvider string   `json:"ci_provider,omitempty"`
+	CIRunID    string   `json:"ci_run_id,omitempty"`
+	CIRunURL   string   `json:"ci_run_url,omitempty"`
+	Warnings   []string `json:"warnings,omitempty"`
+}
+
+// MarshalJSON implements the json.Marshaler interface. The fields use the
+// Range keys, with the timestamp in RFC 3339 format and omitted if unset, and
+// the warnings included for information.
+func (v Version) MarshalJSON() ([]byte, error) {
+	j := versionJSON{
+		Version:    v.Semver(),
+		GitHash:    v.githash,
+		GitBranch:  v.gitbranch,
+		GitUser:    v.gituser,
+		GitTag:     v.gittag,
+		OS:         v.os,
+		Arch:       v.arch,
+		Compiler:   v.compiler,
+		Release:    v.release,
+		CIProvider: v.ciprovider,
+		CIRunID:    v.cirunid,
+		CIRunURL:   v.cirunurl,
+		Warnings:   v.Warnings(),
+	}
+	if !v.timestamp.IsZero() {
+		j.Timestamp = v.TStamp()
+	}
+	return json.Marshal(j)
+}
+
+// Info is a flat copy of the version information using only strings and
+// fixed-size integers, for copying into generated message types such as
+// protobufs. Timestamp is in RFC 3339 format, and it and TimestampUnix are
+// empty and 0 if the timestamp is unset.
+type Info struct {
+	Version       string
+	Major         int64
+	Minor         int64
+	Patch         int64
+	PreRelease    string
+	BuildMetadata string
+	GitHash       string
+	GitBranch     string
+	GitUser       string
+	GitTag        string
+	OS            string
+	Arch          string
+	Compiler      string
+	Release       string
+	Timestamp     string
+	TimestampUnix int64
+	CIProvider    string
+	CIRunID       string
+	CIRunURL      string
+	Warnings      []string
+}
+
+// ToInfo returns the version information as an Info.
+func (v Version) ToInfo() Info {
+	i := Info{
+		Version:       v.Semver(),
+		Major:         int64(v.semver.Major),
+		Minor:         int64(v.semver.Minor),
+		Patch:         int64(v.semver.Patch),
+		BuildMetadata: strings.Join(v.semver.Build, "."),
+		GitHash:       v.githash,
+		GitBranch:     v.gitbranch,
+		GitUser:       v.gituser,
+		GitTag:        v.gittag,
+		OS:            v.os,
+		Arch:          v.arch,
+		Compiler:      v.compiler,
+		Release:       v.release,
+		TimestampUnix: v.TStampUnix(),
+		CIProvider:    v.ciprovider,
+		CIRunID:       v.cirunid,
+		CIRunURL:      v.cirunurl,
+		Warnings:      v.Warnings(),
+	}
+	pre := make([]string, len(v.semver.Pre))
+	for n, id := range v.semver.Pre {
+		pre[n] = id.String()
+	}
+	i.PreRelease = strings.Join(pre, ".")
+	if !v.timestamp.IsZero() {
+		i.Timestamp = v.TStamp()
+	}
+	return i
+}
+
+// QueryValues returns the version information as url.Values keyed by the
+// Range keys, for passing as query parameters.
+func (v Version) QueryValues() url.Values {
+	q := url.Values{}
+	v.Range(func(key, value string) {
+		q.Set(key, value)
+	})
+	return q
+}
+
+// htmlTemplate renders the version fields as a definition list.
+var htmlTemplate = template.Must(template.New("version").Parse(
+	`<dl class="version">{{range .}}<dt>{{.Key}}</dt><dd>{{.Value}}</dd>{{end}}</dl>`,
+))
+
+// HTML returns the version information as an HTML definition list for
+// embedding in status pages, with the field values escaped.
+func (v Version) HTML() template.HTML {
+	var fields []struct{ Key, Value string }
+	v.Range(func(key, value string) {
+		fields = append(fields, struct{ Key, Value string }{key, value})
+	})
+
+	var b strings.Builder
+	if err := htmlTemplate.Execute(&b, fields); err != nil {
+		return ""
+	}
+	return template.HTML(b.String())
+}
+
+// SPDXFields returns the version information mapped to SPDX package fields:
+// PackageVersion holds the semver string, SourceInfo the git commit and
+// branch the package was built from, and BuiltDate the RFC 3339 timestamp.
+// SourceInfo and BuiltDate are empty if the git hash or timestamp is unset.
+func (v Version) SPDXFields() map[string]string {
+	fields := map[string]string{
+		"PackageVersion": v.Semver(),
+		"SourceInfo":     "",
+		"BuiltDate":      "",
+	}
+	if v.githash != "" {
+		fields["SourceInfo"] = "built from git commit " + v.githash
+		if v.gitbranch != "" {
+			fields["SourceInfo"] += " on branch " + v.gitbranch
+		}
+	}
+	if !v.timestamp.IsZero() {
+		fields["BuiltDate"] = v.TStamp()
+	}
+	return fields
+}
+
+// ReleaseHeader returns a Keep a Changelog release heading for the version,
+// such as "## [1.2.3] - 2019-02-14", using the core version and the date of
+// the timestamp. The date is omitted if the timestamp is not set.
+func (v Version) ReleaseHeader() string {
+	core := fmt.Sprintf("%d.%d.%d", v.semver.Major, v.semver.Minor, v.semver.Patch)
+	if v.timestamp.IsZero() {
+		return "## [" + core + "]"
+	}
+	return "## [" + core + "] - " + v.timestamp.Format("2006-01-02")
+}
+
+// SlogAttrs returns one string attribute per metadata field, keyed by the
+// Range keys, for adding to a log record.
+func (v Version) SlogAttrs() []slog.Attr {
+	var attrs []slog.Attr
+	v.Range(func(key, value string) {
+		attrs = append(attrs, slog.String(key, value))
+	})
+	return attrs
+}
+
+// MetricLabels returns the version information as a stable set of metric
+// labels: version, revision (the git hash), branch, goversion (the
+// compiler), os and arch. The label keys won't change, so the map can be
+// passed to Prometheus, OpenTelemetry or other metrics libraries.
+func (v Version) MetricLabels() map[string]string {
+	return map[string]string{
+		"version":   v.Semver(),
+		"revision":  v.githash,
+		"branch":    v.gitbranch,
+		"goversion": v.compiler,
+		"os":        v.os,
+		"arch":      v.arch,
+	}
+}
@@ -0,0 +1,486 @@
+package govee
+
+import (
+	"encoding/json"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDotEnv(t *testing.T) {
+	expect := `APP_VERSION=1.2.3
+APP_GIT_HASH=1234567890abcdef
+APP_GIT_BRANCH=testing
+APP_GIT_USER="Jane Doe"
+APP_GIT_TAG=
+APP_OS=linux
+APP_ARCH=amd64
+APP_COMPILER=go1.11.1
+APP_RELEASE=prod
+APP_TIMESTAMP=2019-02-14T15:04:05Z
+`
+
+	vconf := testConfig()
+	v, err := NewVersion(&vconf)
+	if err != nil {
+		t.Error(err)
+	}
+	if v.DotEnv("APP") != expect {
+		t.Errorf("Expected %s, got %s", expect, v.DotEnv("APP"))
+	}
+}
+
+func TestDotEnvQuote(t *testing.T) {
+	tests := map[string]string{
+		"1.2.3":                        "1.2.3",
+		"Thu Feb 14 15:04:05 UTC 2019": `"Thu Feb 14 15:04:05 UTC 2019"`,
+		`say "$HOME"`:                  `"say \"\$HOME\""`,
+		"feat\nX=1":                    `"feat\nX=1"`,
+		"line\r\n":                     `"line\r\n"`,
+		"":                             "",
+	}
+
+	for in, expect := range tests {
+		if got := dotEnvQuote(in); got != expect {
+			t.Errorf("Expected %s, got %s", expect, got)
+		}
+	}
+}
+
+func TestDotEnvNewline(t *testing.T) {
+	vconf := testConfig()
+	vconf.GitBranch = "feat\nX=1"
+	v, err := NewVersion(&vconf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(v.DotEnv("APP"), "\n"), "\n") {
+		if !strings.HasPrefix(line, "APP_") {
+			t.Errorf("Expected every line to set an APP_ variable, got %q", line)
+		}
+	}
+}
+
+func TestRange(t *testing.T) {
+	expectKeys := []string{
+		"version", "git_hash", "git_branch", "git_user", "git_tag", "os", "arch", "compiler", "release", "timestamp",
+	}
+	expectValues := []string{
+		"1.2.3", "1234567890abcdef", "testing", "Jane Doe", "", "linux", "amd64", "go1.11.1", "prod", "2019-02-14T15:04:05Z",
+	}
+
+	vconf := testConfig()
+	v, err := NewVersion(&vconf)
+	if err != nil {
+		t.Error(err)
+	}
+
+	var keys, values []string
+	v.Range(func(key, value string) {
+		keys = append(keys, key)
+		values = append(values, value)
+	})
+
+	if len(keys) != len(expectKeys) {
+		t.Fatalf("Expected %d fields, got %d", len(expectKeys), len(keys))
+	}
+	for i := range expectKeys {
+		if keys[i] != expectKeys[i] {
+			t.Errorf("Expected key %s, got %s", expectKeys[i], keys[i])
+		}
+		if values[i] != expectValues[i] {
+			t.Errorf("Expected %s=%s, got %s", keys[i], expectValues[i], values[i])
+		}
+	}
+}
+
+func TestRangeNoTimestamp(t *testing.T) {
+	v, err := ParseVersionString("1.2.3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v.Range(func(key, value string) {
+		if key == "timestamp" && value != "" {
+			t.Errorf("Expected an empty timestamp, got %s", value)
+		}
+	})
+	if ts := v.Provenance()["timestamp"]; ts != "" {
+		t.Errorf("Expected an empty provenance timestamp, got %s", ts)
+	}
+	if strings.Contains(v.DotEnv(""), "0001") {
+		t.Errorf("Expected no zero time in %q", v.DotEnv(""))
+	}
+}
+
+func TestDockerTag(t *testing.T) {
+	expect := "1.2.3-rc.1_ci.1234"
+
+	vconf := testConfig()
+	vconf.VersionString = "1.2.3-rc.1+ci.1234"
+	v, err := NewVersion(&vconf)
+	if err != nil {
+		t.Error(err)
+	}
+	if v.DockerTag() != expect {
+		t.Errorf("Expected %s, got %s", expect, v.DockerTag())
+	}
+}
+
+func TestDockerTagTruncate(t *testing.T) {
+	expect := 128
+
+	vconf := testConfig()
+	vconf.VersionString = "1.2.3+" + strings.Repeat("a", 200)
+	v, err := NewVersion(&vconf)
+	if err != nil {
+		t.Error(err)
+	}
+	if len(v.DockerTag()) != expect {
+		t.Errorf("Expected %d characters, got %d", expect, len(v.DockerTag()))
+	}
+	if !strings.HasPrefix(v.DockerTag(), "1.2.3_aaa") {
+		t.Errorf("Expected a 1.2.3_aaa prefix, got %s", v.DockerTag())
+	}
+}
+
+func TestUserAgent(t *testing.T) {
+	vconf := testConfig()
+	v, err := NewVersion(&vconf)
+	if err != nil {
+		t.Error(err)
+	}
+
+	expect := "myapp/1.2.3 (linux/amd64)"
+	if v.UserAgent("myapp") != expect {
+		t.Errorf("Expected %s, got %s", expect, v.UserAgent("myapp"))
+	}
+
+	expect = "1.2.3 (linux/amd64)"
+	if v.UserAgent("") != expect {
+		t.Errorf("Expected %s, got %s", expect, v.UserAgent(""))
+	}
+}
+
+func TestUserAgentNoPlatform(t *testing.T) {
+	vconf := testConfig()
+	vconf.OS = ""
+	vconf.Arch = ""
+	v, err := NewVersion(&vconf)
+	if err != nil {
+		t.Error(err)
+	}
+
+	expect := "myapp/1.2.3"
+	if v.UserAgent("myapp") != expect {
+		t.Errorf("Expected %s, got %s", expect, v.UserAgent("myapp"))
+	}
+
+	vconf.OS = "linux"
+	v, err = NewVersion(&vconf)
+	if err != nil {
+		t.Error(err)
+	}
+	expect = "myapp/1.2.3 (linux)"
+	if v.UserAgent("myapp") != expect {
+		t.Errorf("Expected %s, got %s", expect, v.UserAgent("myapp"))
+	}
+}
+
+func TestSlug(t *testing.T) {
+	tests := map[string]string{
+		"1.2.3":              "v1-2-3",
+		"1.2.3-RC.1":         "v1-2-3-rc-1",
+		"1.2.3-beta+ci.1234": "v1-2-3-beta-ci-1234",
+	}
+
+	for in, expect := range tests {
+		vconf := testConfig()
+		vconf.VersionString = in
+		v, err := NewVersion(&vconf)
+		if err != nil {
+			t.Error(err)
+		}
+		if v.Slug() != expect {
+			t.Errorf("Expected %s, got %s", expect, v.Slug())
+		}
+	}
+}
+
+func TestProvenance(t *testing.T) {
+	expect := map[string]string{
+		"git_hash":   "1234567890abcdef",
+		"git_branch": "testing",
+		"git_user":   "Jane Doe",
+		"timestamp":  "2019-02-14T15:04:05Z",
+	}
+
+	v := testVersion(t, "1.2.3")
+	p := v.Provenance()
+
+	if len(p) != len(expect) {
+		t.Errorf("Expected %d keys, got %d: %v", len(expect), len(p), p)
+	}
+	for key, value := range expect {
+		if p[key] != value {
+			t.Errorf("Expected %s=%s, got %s", key, value, p[key])
+		}
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	expect := `{"version":"1.2.3","git_hash":"1234567890abcdef","git_branch":"testing","git_user":"Jane Doe",` +
+		`"os":"linux","arch":"amd64","compiler":"go1.11.1","release":"prod","timestamp":"2019-02-14T15:04:05Z"}`
+
+	v := testVersion(t, "1.2.3")
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != expect {
+		t.Errorf("Expected %s, got %s", expect, b)
+	}
+}
+
+func TestToInfo(t *testing.T) {
+	vconf := testConfig()
+	vconf.VersionString = "1.2.3-rc.1+ci.4"
+	vconf.GitTag = "v1.2.3-rc.1"
+	vconf.CIProvider = "github-actions"
+	vconf.CIRunID = "1234"
+	vconf.CIRunURL = "https://example.com/runs/1234"
+	v, err := NewVersion(&vconf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect := Info{
+		Version:       v.Semver(),
+		Major:         int64(v.Major()),
+		Minor:         int64(v.Minor()),
+		Patch:         int64(v.Patch()),
+		PreRelease:    "rc.1",
+		BuildMetadata: "ci.4",
+		GitHash:       v.GitHash(),
+		GitBranch:     v.GitBranch(),
+		GitUser:       v.GitUser(),
+		GitTag:        v.GitTag(),
+		OS:            v.OS(),
+		Arch:          v.Arch(),
+		Compiler:      v.Compiler(),
+		Release:       v.Release(),
+		Timestamp:     v.TStamp(),
+		TimestampUnix: v.TStampUnix(),
+		CIProvider:    v.CIProvider(),
+		CIRunID:       v.CIRunID(),
+		CIRunURL:      v.CIRunURL(),
+		Warnings:      v.Warnings(),
+	}
+	if got := v.ToInfo(); !reflect.DeepEqual(got, expect) {
+		t.Errorf("Expected %#v, got %#v", expect, got)
+	}
+}
+
+func TestToInfoNoTimestamp(t *testing.T) {
+	v, err := ParseVersionString("1.2.3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	i := v.ToInfo()
+	if i.Timestamp != "" || i.TimestampUnix != 0 {
+		t.Errorf("Expected an unset timestamp, got %q and %d", i.Timestamp, i.TimestampUnix)
+	}
+	if i.PreRelease != "" || i.BuildMetadata != "" {
+		t.Errorf("Expected no pre-release or build metadata, got %q and %q", i.PreRelease, i.BuildMetadata)
+	}
+}
+
+func TestQueryValues(t *testing.T) {
+	expect := "arch=amd64&compiler=go1.11.1&git_branch=testing&git_hash=1234567890abcdef&git_tag=&git_user=Jane+Doe" +
+		"&os=linux&release=prod&timestamp=2019-02-14T15%3A04%3A05Z&version=1.2.3"
+
+	v := testVersion(t, "1.2.3")
+	q := v.QueryValues()
+	if q.Encode() != expect {
+		t.Errorf("Expected %s, got %s", expect, q.Encode())
+	}
+	if q.Get("git_user") != "Jane Doe" {
+		t.Errorf("Expected Jane Doe, got %s", q.Get("git_user"))
+	}
+}
+
+func TestHTML(t *testing.T) {
+	expect := `<dt>git_user</dt><dd>&lt;script&gt;alert(&#34;Jane&#34;)&lt;/script&gt;</dd>`
+
+	vconf := testConfig()
+	vconf.GitUser = `<script>alert("Jane")</script>`
+	v, err := NewVersion(&vconf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	html := string(v.HTML())
+	if !strings.HasPrefix(html, `<dl class="version"><dt>version</dt><dd>1.2.3</dd>`) {
+		t.Errorf("Expected a definition list, got %s", html)
+	}
+	if !strings.Contains(html, expect) {
+		t.Errorf("Expected %s in %s", expect, html)
+	}
+	if strings.Contains(html, "<script>") {
+		t.Errorf("Expected the git user to be escaped, got %s", html)
+	}
+}
+
+func TestSPDXFields(t *testing.T) {
+	expect := map[string]string{
+		"PackageVersion": "1.2.3",
+		"SourceInfo":     "built from git commit 1234567890abcdef on branch testing",
+		"BuiltDate":      "2019-02-14T15:04:05Z",
+	}
+
+	v := testVersion(t, "1.2.3")
+	fields := v.SPDXFields()
+
+	if len(fields) != len(expect) {
+		t.Errorf("Expected %d fields, got %d: %v", len(expect), len(fields), fields)
+	}
+	for key, value := range expect {
+		if fields[key] != value {
+			t.Errorf("Expected %s=%s, got %s", key, value, fields[key])
+		}
+	}
+}
+
+func TestProvenanceGitTag(t *testing.T) {
+	expect := "semver/1.2.3"
+
+	vconf := testConfig()
+	vconf.GitTag = expect
+	v, err := NewVersion(&vconf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Provenance()["git_tag"] != expect {
+		t.Errorf("Expected %s, got %s", expect, v.Provenance()["git_tag"])
+	}
+}
+
+func TestReleaseHeader(t *testing.T) {
+	expect := "## [1.2.3] - 2019-02-14"
+
+	v := testVersion(t, "1.2.3-rc.1+ci.1234")
+	if v.ReleaseHeader() != expect {
+		t.Errorf("Expected %s, got %s", expect, v.ReleaseHeader())
+	}
+}
+
+func TestReleaseHeaderNoTimestamp(t *testing.T) {
+	expect := "## [1.2.3]"
+
+	v, err := ParseVersionString("1.2.3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.ReleaseHeader() != expect {
+		t.Errorf("Expected %s, got %s", expect, v.ReleaseHeader())
+	}
+}
+
+func TestSlogAttrs(t *testing.T) {
+	v := testVersion(t, "1.2.3")
+
+	var expect []string
+	v.Range(func(key, value string) {
+		expect = append(expect, key+"="+value)
+	})
+
+	attrs := v.SlogAttrs()
+	if len(attrs) != len(expect) {
+		t.Fatalf("Expected %d attributes, got %d", len(expect), len(attrs))
+	}
+	for i, attr := range attrs {
+		if attr.Value.Kind() != slog.KindString {
+			t.Errorf("Expected %s to be a string, got %s", attr.Key, attr.Value.Kind())
+		}
+		if got := attr.Key + "=" + attr.Value.String(); got != expect[i] {
+			t.Errorf("Expected %s, got %s", expect[i], got)
+		}
+	}
+}
+
+func TestCIFields(t *testing.T) {
+	expect := `{"version":"1.2.3","git_hash":"1234567890abcdef","git_branch":"testing","git_user":"Jane Doe",` +
+		`"os":"linux","arch":"amd64","compiler":"go1.11.1","release":"prod","timestamp":"2019-02-14T15:04:05Z",` +
+		`"ci_provider":"github-actions","ci_run_id":"1234","ci_run_url":"https://example.com/runs/1234"}`
+
+	vconf := testConfig()
+	vconf.CIProvider = "github-actions"
+	vconf.CIRunID = "1234"
+	vconf.CIRunURL = "https://example.com/runs/1234"
+	v, err := NewVersion(&vconf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v.CIProvider() != vconf.CIProvider || v.CIRunID() != vconf.CIRunID || v.CIRunURL() != vconf.CIRunURL {
+		t.Errorf("Expected %s/%s/%s, got %s/%s/%s",
+			vconf.CIProvider, vconf.CIRunID, vconf.CIRunURL, v.CIProvider(), v.CIRunID(), v.CIRunURL())
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != expect {
+		t.Errorf("Expected %s, got %s", expect, b)
+	}
+
+	fields := map[string]string{}
+	v.Range(func(key, value string) {
+		fields[key] = value
+	})
+	if fields["ci_run_id"] != vconf.CIRunID {
+		t.Errorf("Expected ci_run_id=%s, got %v", vconf.CIRunID, fields)
+	}
+}
+
+func TestCIFieldsUnset(t *testing.T) {
+	v := testVersion(t, "1.2.3")
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(b), "ci_") {
+		t.Errorf("Expected no CI fields, got %s", b)
+	}
+	v.Range(func(key, value string) {
+		if strings.HasPrefix(key, "ci_") {
+			t.Errorf("Expected no CI fields, got %s", key)
+		}
+	})
+}
+
+func TestMetricLabels(t *testing.T) {
+	expect := map[string]string{
+		"version":   "1.2.3",
+		"revision":  "1234567890abcdef",
+		"branch":    "testing",
+		"goversion": "go1.11.1",
+		"os":        "linux",
+		"arch":      "amd64",
+	}
+
+	labels := testVersion(t, "1.2.3").MetricLabels()
+	if len(labels) != len(expect) {
+		t.Errorf("Expected %d labels, got %d: %v", len(expect), len(labels), labels)
+	}
+	for key, value := range expect {
+		if got, ok := labels[key]; !ok || got != value {
+			t.Errorf("Expected %s=%s, got %s", key, value, got)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package govee
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+)
+
+// Fingerprint returns a hex-encoded SHA-256 digest of the version's metadata
+// fields, as listed by Range. It identifies a particular build: two versions
+// have the same fingerprint only if all their metadata is the same.
+func (v Version) Fingerprint() string {
+	h := sha256.New()
+	v.Range(func(key, value string) {
+		h.Write([]byte(key + "=" + value + "\n"))
+	})
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Color returns a hex color such as "#3fa2c9" derived from the fingerprint,
+// so that the same build is always shown in the same color. It is purely
+// cosmetic and carries no meaning.
+func (v Version) Color() string {
+	return "#" + v.Fingerprint()[:6]
+}
+
+// BuildID returns an opaque identifier for the build, made of the first seven
+// characters of the git hash and the first four of the fingerprint, such as
+// "a1b2c3d-4f5e". If the git hash is not set, only the fingerprint part is
+// returned.
+func (v Version) BuildID() string {
+	fp := v.Fingerprint()[:4]
+	hash := v.githash
+	if len(hash) > 7 {
+		hash = hash[:7]
+	}
+	if hash == "" {
+		return fp
+	}
+	return hash + "-" + fp
+}
+
+// Bucket returns a number in [0, 100) derived from the fingerprint and salt,
+// for rolling a feature out to a percentage of builds. A build always lands
+// in the same bucket for a given salt, while different salts spread builds
+// independently.
+func (v Version) Bucket(salt string) int {
+	sum := sha256.Sum256([]byte(v.Fingerprint() + salt))
+	return int(binary.BigEndian.Uint64(sum[:8]) % 100)
+}
+
+// ETag returns a strong HTTP entity tag derived from the fingerprint, such as
+// "sha256-3fa2...", including the surrounding double quotes, so it can be set
+// as the ETag header and compared with If-None-Match as is.
+func (v Version) ETag() string {
+	return `"sha256-` + v.Fingerprint() + `"`
+}
@@ -0,0 +1,127 @@
+package govee
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+func TestFingerprint(t *testing.T) {
+	a := testVersion(t, "1.2.3")
+	b := testVersion(t, "1.2.3")
+
+	if len(a.Fingerprint()) != 64 {
+		t.Errorf("Expected a 64 character fingerprint, got %s", a.Fingerprint())
+	}
+	if a.Fingerprint() != b.Fingerprint() {
+		t.Errorf("Expected %s, got %s", a.Fingerprint(), b.Fingerprint())
+	}
+
+	c := testPlatformVersion(t, "1.2.3", "a1b2c3d", "linux")
+	if a.Fingerprint() == c.Fingerprint() {
+		t.Errorf("Expected different builds to have different fingerprints, got %s", c.Fingerprint())
+	}
+}
+
+func TestColor(t *testing.T) {
+	format := regexp.MustCompile(`^#[0-9a-f]{6}$`)
+
+	a := testVersion(t, "1.2.3")
+	if !format.MatchString(a.Color()) {
+		t.Errorf("Expected a hex color, got %s", a.Color())
+	}
+	if a.Color() != testVersion(t, "1.2.3").Color() {
+		t.Errorf("Expected the color of %s to be deterministic", a)
+	}
+
+	colors := map[string]bool{}
+	for _, hash := range []string{"a1b2c3d", "b2c3d4e", "c3d4e5f", "d4e5f6a", "e5f6a7b"} {
+		colors[testPlatformVersion(t, "1.2.3", hash, "linux").Color()] = true
+	}
+	if len(colors) < 4 {
+		t.Errorf("Expected different hashes to generally have different colors, got %v", colors)
+	}
+}
+
+func TestBuildID(t *testing.T) {
+	format := regexp.MustCompile(`^1234567-[0-9a-f]{4}$`)
+
+	a := testVersion(t, "1.2.3")
+	if !format.MatchString(a.BuildID()) {
+		t.Errorf("Expected 1234567-xxxx, got %s", a.BuildID())
+	}
+	if a.BuildID() != testVersion(t, "1.2.3").BuildID() {
+		t.Errorf("Expected the build ID of %s to be deterministic", a)
+	}
+	if expect := "1234567-" + a.Fingerprint()[:4]; a.BuildID() != expect {
+		t.Errorf("Expected %s, got %s", expect, a.BuildID())
+	}
+
+	b := testVersion(t, "1.2.4")
+	if a.BuildID() == b.BuildID() {
+		t.Errorf("Expected different builds to have different IDs, got %s", b.BuildID())
+	}
+
+	c, err := ParseVersionString("1.2.3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !regexp.MustCompile(`^[0-9a-f]{4}$`).MatchString(c.BuildID()) {
+		t.Errorf("Expected only the fingerprint part without a git hash, got %s", c.BuildID())
+	}
+}
+
+func TestBucket(t *testing.T) {
+	a := testVersion(t, "1.2.3")
+	b := a.Bucket("new-ui")
+
+	if b < 0 || b >= 100 {
+		t.Errorf("Expected a bucket in [0, 100), got %d", b)
+	}
+	if testVersion(t, "1.2.3").Bucket("new-ui") != b {
+		t.Errorf("Expected the bucket of %s to be stable", a)
+	}
+}
+
+func TestBucketDistribution(t *testing.T) {
+	counts := make([]int, 10)
+	salted := 0
+	for i := 0; i < 1000; i++ {
+		v := testPlatformVersion(t, "1.2.3", fmt.Sprintf("%07x", i), "linux")
+		b := v.Bucket("new-ui")
+		if b < 0 || b >= 100 {
+			t.Fatalf("Expected a bucket in [0, 100), got %d", b)
+		}
+		counts[b/10]++
+		if v.Bucket("dark-mode") != b {
+			salted++
+		}
+	}
+
+	for i, n := range counts {
+		if n < 50 || n > 150 {
+			t.Errorf("Expected about 100 builds in buckets %d-%d, got %d", i*10, i*10+9, n)
+		}
+	}
+	if salted < 900 {
+		t.Errorf("Expected a different salt to move most builds, moved %d", salted)
+	}
+}
+
+func TestETag(t *testing.T) {
+	format := regexp.MustCompile(`^"sha256-[0-9a-f]{64}"$`)
+
+	a := testVersion(t, "1.2.3")
+	if !format.MatchString(a.ETag()) {
+		t.Errorf("Expected a quoted sha256 tag, got %s", a.ETag())
+	}
+	if expect := `"sha256-` + a.Fingerprint() + `"`; a.ETag() != expect {
+		t.Errorf("Expected %s, got %s", expect, a.ETag())
+	}
+	if a.ETag() != testVersion(t, "1.2.3").ETag() {
+		t.Errorf("Expected the ETag of %s to be stable", a)
+	}
+	if a.ETag() == testVersion(t, "1.2.4").ETag() {
+		t.Errorf("Expected different builds to have different ETags, got %s", a.ETag())
+	}
+}
@@ -1,7 +1,13 @@
 package govee
 
 import (
+	"errors"
 	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/blang/semver"
@@ -9,17 +15,29 @@ import (
 
 // Version represents a semantic version number.
 type Version struct {
-	semver    semver.Version
-	githash   string
-	gitbranch string
-	gituser   string
-	os        string
-	arch      string
-	compiler  string
-	release   string
-	timestamp time.Time
-	warnings  []string
-	err       error
+	semver     semver.Version
+	githash    string
+	gitbranch  string
+	gituser    string
+	gittag     string
+	os         string
+	arch       string
+	compiler   string
+	release    string
+	ciprovider string
+	cirunid    string
+	cirunurl   string
+	timestamp  time.Time
+	badtstamp  string // unparseable timestamp tolerated by TimestampTolerant.
+	noversion  bool   // defaulted 0.0.0 until a bump sets a version.
+	novcs      bool   // built without VCS stamping, see FromGoVersionM.
+	nobranch   bool   // source records no git branch, so HEAD may not be detached.
+	warn0x     bool
+	relcase    bool // release labels compared case-sensitively.
+	mingo      string
+	branchrel  map[string][]string
+	warnings   []Warning
+	err        error
 }
 
 // VersionConfig represents the version coniguration.
@@ -28,51 +46,376 @@ type VersionConfig struct {
 	GitHash       string
 	GitBranch     string
 	GitUser       string
+	GitTag        string // tag the build was made from, e.g. semver/1.2.3.
 	OS            string
 	Arch          string
 	Compiler      string
-	Release       string
-	TStamp        string
+	Release       string // surrounding whitespace is ignored.
+	TStamp        string // UnixDate, RFC 3339 or Unix epoch seconds.
+
+	// CIProvider, CIRunID and CIRunURL optionally identify the CI run that
+	// produced the build, e.g. "github-actions", "1234" and its URL.
+	CIProvider string
+	CIRunID    string
+	CIRunURL   string
+
+	// TimestampLocation is used to interpret TStamp when it carries no
+	// reliable zone. When set, the timestamp is normalized to UTC.
+	TimestampLocation *time.Location
+
+	// TimestampTolerant makes NewVersion accept a TStamp that can't be
+	// parsed, leaving the timestamp unset and adding a warning instead of
+	// returning an error.
+	TimestampTolerant bool
+
+	// TimestampParser, if set, is used to parse TStamp instead of the
+	// built-in formats, and TimestampLocation is ignored.
+	TimestampParser func(string) (time.Time, error)
+
+	// VersionTolerant makes NewVersion accept an empty VersionString,
+	// defaulting to 0.0.0 and adding a warning instead of returning
+	// ErrEmptyVersion.
+	VersionTolerant bool
+
+	// Warn0x adds a warning for versions below 1.0.0, which semver reserves
+	// for initial development, regardless of the release label.
+	Warn0x bool
+
+	// BranchReleases maps git branches to the release labels expected to be
+	// built from them, e.g. "master" to {"prod"} and "feature/*" to
+	// {"test", "dev"}. Keys are path.Match patterns. If the git branch
+	// matches a key but the release label isn't listed, a warning is added.
+	// Branches that match no key aren't checked.
+	BranchReleases map[string][]string
+
+	// ReleaseCaseSensitive makes release labels compare case-sensitively
+	// when deciding whether a build is production and when checking
+	// BranchReleases. By default "Prod" counts as "prod".
+	ReleaseCaseSensitive bool
+
+	// MinGoVersion, if set, is the oldest Go toolchain the build may be
+	// compiled with, e.g. "go1.20". If Compiler names an older release, a
+	// critical warning is added.
+	MinGoVersion string
+
+	// NoVCS marks a build made without version control information, such as
+	// one read by FromGoVersionM, and adds a critical warning.
+	NoVCS bool
+
+	// BranchUnknown marks a source that records no git branch, such as OCI
+	// labels, so that an empty GitBranch isn't reported as a detached HEAD.
+	BranchUnknown bool
+
+	// Validators are run by NewVersion on the constructed version, in order,
+	// for checks of its own such as "patch must be below 100". If any
+	// return an error, NewVersion returns them all joined together.
+	Validators []func(Version) error
 }
 
-// NewVersion creates a new version object from a VersionConfig.
+// NewVersion creates a new version object from a VersionConfig. Whitespace
+// surrounding the VersionString is ignored. A major, minor or patch number
+// above MaxComponent returns a *ComponentRangeError, and any other malformed
+// VersionString returns an error wrapping ErrInvalidVersion.
 func NewVersion(c *VersionConfig) (Version, error) {
 	var err error
 	v := Version{}
 	v.githash = c.GitHash
 	v.gitbranch = c.GitBranch
 	v.gituser = c.GitUser
+	v.gittag = c.GitTag
 	v.os = c.OS
 	v.arch = c.Arch
 	v.compiler = c.Compiler
-	v.release = c.Release
+	v.release = strings.TrimSpace(c.Release)
+	v.ciprovider = c.CIProvider
+	v.cirunid = c.CIRunID
+	v.cirunurl = c.CIRunURL
+	v.warn0x = c.Warn0x
+	v.relcase = c.ReleaseCaseSensitive
+	v.branchrel = copyBranchReleases(c.BranchReleases)
+	v.mingo = c.MinGoVersion
+	v.novcs = c.NoVCS
+	v.nobranch = c.BranchUnknown
 
-	v.semver, err = semver.Make(c.VersionString)
-	if err != nil {
-		return Version{}, err
+	if v.mingo != "" {
+		if _, ok := parseGoVersion(v.mingo); !ok {
+			return Version{}, fmt.Errorf("govee: invalid minimum Go version %q", v.mingo)
+		}
+	}
+
+	vs := strings.TrimSpace(c.VersionString)
+	if vs == "" {
+		if !c.VersionTolerant {
+			return Version{}, ErrEmptyVersion
+		}
+		v.noversion = true
+	} else {
+		if err := checkComponents(vs); err != nil {
+			return Version{}, err
+		}
+		v.semver, err = semver.Make(vs)
+		if err != nil {
+			return Version{}, fmt.Errorf("%w: %s", ErrInvalidVersion, err)
+		}
 	}
 
-	v.timestamp, err = time.Parse(time.UnixDate, c.TStamp)
+	v.timestamp, err = c.parseTimestamp()
 	if err != nil {
-		return Version{}, err
+		if !c.TimestampTolerant {
+			return Version{}, err
+		}
+		v.timestamp = time.Time{}
+		v.badtstamp = c.TStamp
+	}
+
+	v.setWarnings()
+
+	var errs []error
+	for _, validate := range c.Validators {
+		if validate == nil {
+			continue
+		}
+		if err := validate(v); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return Version{}, fmt.Errorf("govee: version %s failed validation: %w", v, errors.Join(errs...))
+	}
+	return v, nil
+}
+
+// parseTimestamp parses TStamp using the TimestampParser if set. Otherwise it
+// tries, in order, the UnixDate layout produced by date(1), the RFC 3339 form
+// produced by git's %cI format, and the Unix epoch seconds produced by %ct.
+func (c *VersionConfig) parseTimestamp() (time.Time, error) {
+	if c.TimestampParser != nil {
+		return c.TimestampParser(c.TStamp)
+	}
+
+	for _, layout := range []string{time.UnixDate, time.RFC3339} {
+		if c.TimestampLocation != nil {
+			if t, err := time.ParseInLocation(layout, c.TStamp, c.TimestampLocation); err == nil {
+				return t.UTC(), nil
+			}
+		} else if t, err := time.Parse(layout, c.TStamp); err == nil {
+			return t, nil
+		}
+	}
+	if sec, err := strconv.ParseInt(c.TStamp, 10, 64); err == nil {
+		return time.Unix(sec, 0).UTC(), nil
+	}
+	return time.Time{}, fmt.Errorf("%w: %q is not in UnixDate, RFC 3339 or Unix epoch format", ErrInvalidTimestamp, c.TStamp)
+}
+
+// setWarnings recomputes the version warnings from the version information.
+func (v *Version) setWarnings() {
+	v.warnings = nil
+
+	if v.noversion {
+		v.warn(WarningEmptyVersion, SeverityCritical,
+			"No version string was set at compile time. Defaulting to 0.0.0.",
+		)
 	}
 
 	if len(v.semver.Pre) > 0 {
-		warning := fmt.Sprintf(
+		v.warn(WarningPreRelease, SeverityAdvisory,
 			"This version is tagged as a pre-release \"%+v\". Please don't use in production.",
 			v.semver.Pre,
 		)
-		v.warnings = append(v.warnings, warning)
 	}
 
-	if v.release != "production" && v.release != "prod" {
-		warning := fmt.Sprintf(
+	if v.warn0x && v.semver.Major == 0 {
+		v.warn(WarningInitialDevelopment, SeverityAdvisory,
+			"This version \"%s\" is in initial development and may change at any time.",
+			v.semver,
+		)
+	}
+
+	if !v.isProduction() {
+		v.warn(WarningNonProduction, SeverityAdvisory,
 			"This version is tagged as release \"%s\". Please don't use in production.",
 			v.release,
 		)
-		v.warnings = append(v.warnings, warning)
 	}
-	return v, nil
+
+	if expected, ok := v.expectedReleases(); ok && !v.releaseIn(expected...) {
+		v.warn(WarningBranchRelease, SeverityAdvisory,
+			"This version is tagged as release \"%s\" but was built from branch \"%s\", which expects %q.",
+			v.release, v.gitbranch, expected,
+		)
+	}
+
+	if v.gittag != "" && !v.noversion && !v.matchesTag() {
+		v.warn(WarningTagMismatch, SeverityCritical,
+			"This version \"%s\" does not match the git tag \"%s\".",
+			v.semver, v.gittag,
+		)
+	}
+
+	if v.githash != "" && !v.nobranch && v.IsDetached() {
+		v.warn(WarningDetachedHead, SeverityAdvisory,
+			"This version was built from a detached HEAD and can't be traced to a branch.",
+		)
+	}
+
+	if v.badtstamp != "" {
+		v.warn(WarningInvalidTimestamp, SeverityCritical,
+			"The timestamp \"%s\" could not be parsed and has been ignored.",
+			v.badtstamp,
+		)
+	}
+
+	if v.githash != "" && !validGitHash(v.githash) {
+		v.warn(WarningInvalidGitHash, SeverityCritical,
+			"The git hash \"%s\" is not a valid commit hash. Was it set at compile time?",
+			v.githash,
+		)
+	}
+
+	if v.os != "" && !knownOS[v.os] {
+		v.warn(WarningUnknownOS, SeverityAdvisory,
+			"The OS \"%s\" is not a known GOOS value. Was it cross-compiled correctly?",
+			v.os,
+		)
+	}
+
+	if v.arch != "" && !knownArch[v.arch] {
+		v.warn(WarningUnknownArch, SeverityAdvisory,
+			"The architecture \"%s\" is not a known GOARCH value. Was it cross-compiled correctly?",
+			v.arch,
+		)
+	}
+
+	if v.novcs {
+		v.warn(WarningNoVCS, SeverityCritical,
+			"This version was built without version control information and can't be traced to a commit.",
+		)
+	}
+
+	if v.olderGo() {
+		v.warn(WarningOldGoVersion, SeverityCritical,
+			"This version was built with \"%s\", which is older than the minimum \"%s\".",
+			v.compiler, v.mingo,
+		)
+	}
+}
+
+// warn adds a warning with the given code and severity.
+func (v *Version) warn(code, severity, format string, a ...interface{}) {
+	v.warnings = append(v.warnings, Warning{
+		Code:     code,
+		Severity: severity,
+		Message:  fmt.Sprintf(format, a...),
+	})
+}
+
+// expectedReleases returns the release labels that BranchReleases expects
+// for the git branch, and whether the branch matched a key. An exact match
+// takes priority over patterns, which are tried in sorted order.
+func (v Version) expectedReleases() ([]string, bool) {
+	if v.gitbranch == "" || len(v.branchrel) == 0 {
+		return nil, false
+	}
+	if expected, ok := v.branchrel[v.gitbranch]; ok {
+		return expected, true
+	}
+
+	patterns := make([]string, 0, len(v.branchrel))
+	for pattern := range v.branchrel {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, v.gitbranch); ok {
+			return v.branchrel[pattern], true
+		}
+	}
+	return nil, false
+}
+
+// copyBranchReleases returns a deep copy of m, or nil if m is empty.
+func copyBranchReleases(m map[string][]string) map[string][]string {
+	if len(m) == 0 {
+		return nil
+	}
+	c := make(map[string][]string, len(m))
+	for branch, releases := range m {
+		c[branch] = append([]string(nil), releases...)
+	}
+	return c
+}
+
+// releaseIn reports whether the release label is one of labels. Labels are
+// compared case-insensitively unless ReleaseCaseSensitive was set.
+func (v Version) releaseIn(labels ...string) bool {
+	for _, l := range labels {
+		if l == v.release || !v.relcase && strings.EqualFold(l, v.release) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesTag reports whether the git tag names the same version, ignoring
+// build metadata. A tag prefix ending in "/", such as semver/, and a leading
+// "v" are removed before parsing the tag.
+func (v Version) matchesTag() bool {
+	tag := v.gittag[strings.LastIndex(v.gittag, "/")+1:]
+	tv, err := semver.Make(strings.TrimPrefix(tag, "v"))
+	if err != nil {
+		return false
+	}
+	return v.semver.Equals(tv)
+}
+
+// IsDetached reports whether the version was built from a detached HEAD,
+// i.e. the git branch is empty or "HEAD".
+func (v Version) IsDetached() bool {
+	return v.gitbranch == "" || v.gitbranch == "HEAD"
+}
+
+// isProduction reports whether the release label marks a production build,
+// such as "prod" or, unless ReleaseCaseSensitive was set, "Prod".
+func (v Version) isProduction() bool {
+	return v.releaseIn("production", "prod")
+}
+
+// IsSnapshot reports whether the version should be treated as a throwaway
+// build rather than a release: it has a pre-release, or its release label is
+// not production.
+func (v Version) IsSnapshot() bool {
+	return len(v.semver.Pre) > 0 || !v.isProduction()
+}
+
+// describePattern matches the commit distance and abbreviated hash that git
+// describe appends to the nearest tag, such as 2-ga1b2c3d, at the end of a
+// pre-release, including after a pre-release tag such as rc.1-2-ga1b2c3d.
+var describePattern = regexp.MustCompile(`(^|[.-])[0-9]+-g[0-9a-f]+$`)
+
+// IsTaggedRelease reports whether the build corresponds exactly to a git tag,
+// with no commits since. It is false only when the pre-release is git describe
+// output such as 1.2.3-2-ga1b2c3d or 1.2.3-rc.1-2-ga1b2c3d; tagged
+// pre-releases such as 1.2.3-rc.1 count as tagged.
+func (v Version) IsTaggedRelease() bool {
+	if len(v.semver.Pre) == 0 {
+		return true
+	}
+	return !describePattern.MatchString(strings.Join(v.PreReleaseIDs(), "."))
+}
+
+// validGitHash reports whether s looks like a full or abbreviated git commit
+// hash: 7 to 40 hexadecimal characters.
+func validGitHash(s string) bool {
+	if len(s) < 7 || len(s) > 40 {
+		return false
+	}
+	for _, r := range s {
+		if !(r >= '0' && r <= '9' || r >= 'a' && r <= 'f' || r >= 'A' && r <= 'F') {
+			return false
+		}
+	}
+	return true
 }
 
 // Implement the Stringer interface.
@@ -100,14 +443,22 @@ func (v Version) Patch() int {
 	return int(v.semver.Patch)
 }
 
-// Pre returns the pre-release version information.
+// Pre returns the pre-release version information, or "" if the version is
+// not a pre-release.
 func (v Version) Pre() string {
+	if len(v.semver.Pre) == 0 {
+		return ""
+	}
 	return fmt.Sprintf("%v", v.semver.Pre[0])
 }
 
-// Warnings returns the version warnings.
+// Warnings returns the version warning messages.
 func (v Version) Warnings() []string {
-	return v.warnings
+	var messages []string
+	for _, w := range v.warnings {
+		messages = append(messages, w.Message)
+	}
+	return messages
 }
 
 // Err returns the version error.
@@ -130,6 +481,11 @@ func (v Version) GitUser() string {
 	return v.gituser
 }
 
+// GitTag returns the git tag.
+func (v Version) GitTag() string {
+	return v.gittag
+}
+
 // OS returns the operating system.
 func (v Version) OS() string {
 	return v.os
@@ -150,7 +506,72 @@ func (v Version) TStamp() string {
 	return v.timestamp.Format(time.RFC3339)
 }
 
+// TStampUnix returns the timestamp as seconds since the Unix epoch, or 0 if
+// the timestamp is not set.
+func (v Version) TStampUnix() int64 {
+	if v.timestamp.IsZero() {
+		return 0
+	}
+	return v.timestamp.Unix()
+}
+
+// TStampTime returns the timestamp, or the zero time if it is not set.
+func (v Version) TStampTime() time.Time {
+	return v.timestamp
+}
+
+// CIProvider returns the CI provider.
+func (v Version) CIProvider() string {
+	return v.ciprovider
+}
+
+// CIRunID returns the CI run ID.
+func (v Version) CIRunID() string {
+	return v.cirunid
+}
+
+// CIRunURL returns the CI run URL.
+func (v Version) CIRunURL() string {
+	return v.cirunurl
+}
+
 // Compiler returns the compiler version.
 func (v Version) Compiler() string {
 	return v.compiler
 }
+
+// EqualFull reports whether v and other hold exactly the same information,
+// including build metadata, timestamp and warnings. Timestamps are compared
+// with time.Time.Equal, so differing locations or monotonic clock readings
+// for the same instant are considered equal.
+func (v Version) EqualFull(other Version) bool {
+	if v.semver.String() != other.semver.String() ||
+		v.githash != other.githash ||
+		v.gitbranch != other.gitbranch ||
+		v.gituser != other.gituser ||
+		v.gittag != other.gittag ||
+		v.os != other.os ||
+		v.arch != other.arch ||
+		v.compiler != other.compiler ||
+		v.release != other.release ||
+		v.ciprovider != other.ciprovider ||
+		v.cirunid != other.cirunid ||
+		v.cirunurl != other.cirunurl ||
+		!v.timestamp.Equal(other.timestamp) {
+		return false
+	}
+
+	if len(v.warnings) != len(other.warnings) {
+		return false
+	}
+	for i := range v.warnings {
+		if v.warnings[i] != other.warnings[i] {
+			return false
+		}
+	}
+
+	if v.err == nil || other.err == nil {
+		return v.err == other.err
+	}
+	return v.err.Error() == other.err.Error()
+}
@@ -1,6 +1,11 @@
 package govee
 
-import "testing"
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
 
 func TestNewVersion(t *testing.T) {
 	expect := "1.2.3"
@@ -152,3 +157,513 @@ func TestVersionWarnings(t *testing.T) {
 		t.Errorf("Expected %s, got %s", expect, warnings[0])
 	}
 }
+
+func TestTimestampLocation(t *testing.T) {
+	expect := "2019-02-14T13:04:05Z"
+
+	vconf := VersionConfig{
+		VersionString:     "1.2.3",
+		GitHash:           "1234567890abcdef",
+		GitBranch:         "testing",
+		GitUser:           "Jane Doe",
+		OS:                "linux",
+		Arch:              "amd64",
+		Compiler:          "go1.11.1",
+		Release:           "prod",
+		TStamp:            "Thu Feb 14 15:04:05 SAST 2019",
+		TimestampLocation: time.FixedZone("SAST", 2*60*60),
+	}
+
+	v, err := NewVersion(&vconf)
+	if err != nil {
+		t.Error(err)
+	}
+	if v.TStamp() != expect {
+		t.Errorf("Expected %s, got %s", expect, v.TStamp())
+	}
+}
+
+func TestTimestampNoLocation(t *testing.T) {
+	expect := "2019-02-14T15:04:05Z"
+
+	vconf := VersionConfig{
+		VersionString: "1.2.3",
+		GitHash:       "1234567890abcdef",
+		GitBranch:     "testing",
+		GitUser:       "Jane Doe",
+		OS:            "linux",
+		Arch:          "amd64",
+		Compiler:      "go1.11.1",
+		Release:       "prod",
+		TStamp:        "Thu Feb 14 15:04:05 UTC 2019",
+	}
+
+	v, err := NewVersion(&vconf)
+	if err != nil {
+		t.Error(err)
+	}
+	if v.TStamp() != expect {
+		t.Errorf("Expected %s, got %s", expect, v.TStamp())
+	}
+}
+
+// testConfig returns a valid production VersionConfig for tests to modify.
+func testConfig() VersionConfig {
+	return VersionConfig{
+		VersionString: "1.2.3",
+		GitHash:       "1234567890abcdef",
+		GitBranch:     "testing",
+		GitUser:       "Jane Doe",
+		OS:            "linux",
+		Arch:          "amd64",
+		Compiler:      "go1.11.1",
+		Release:       "prod",
+		TStamp:        "Thu Feb 14 15:04:05 UTC 2019",
+	}
+}
+
+func TestTStampUnix(t *testing.T) {
+	var expect int64 = 1550156645
+
+	vconf := testConfig()
+	v, err := NewVersion(&vconf)
+	if err != nil {
+		t.Error(err)
+	}
+	if v.TStampUnix() != expect {
+		t.Errorf("Expected %d, got %d", expect, v.TStampUnix())
+	}
+}
+
+func TestTStampUnixZero(t *testing.T) {
+	v := Version{}
+	if v.TStampUnix() != 0 {
+		t.Errorf("Expected 0, got %d", v.TStampUnix())
+	}
+}
+
+func TestEqualFull(t *testing.T) {
+	vconf := testConfig()
+	a, err := NewVersion(&vconf)
+	if err != nil {
+		t.Error(err)
+	}
+	b, err := NewVersion(&vconf)
+	if err != nil {
+		t.Error(err)
+	}
+	if !a.EqualFull(b) {
+		t.Errorf("Expected %#v to equal %#v", a, b)
+	}
+
+	vconf.GitBranch = "master"
+	c, err := NewVersion(&vconf)
+	if err != nil {
+		t.Error(err)
+	}
+	if a.EqualFull(c) {
+		t.Errorf("Expected %#v not to equal %#v", a, c)
+	}
+
+	vconf = testConfig()
+	vconf.Release = "test"
+	d, err := NewVersion(&vconf)
+	if err != nil {
+		t.Error(err)
+	}
+	if a.EqualFull(d) {
+		t.Errorf("Expected %#v not to equal %#v (warnings differ)", a, d)
+	}
+}
+
+func TestEqualFullMonotonic(t *testing.T) {
+	now := time.Now()
+
+	a := Version{timestamp: now}
+	b := Version{timestamp: now.Round(0)}
+	if a.timestamp == b.timestamp {
+		t.Fatal("Expected timestamps to differ in their monotonic clock reading")
+	}
+	if !a.EqualFull(b) {
+		t.Error("Expected versions with equal timestamps to be equal")
+	}
+}
+
+func TestGitHashWarning(t *testing.T) {
+	tests := map[string]int{
+		"de6e4f2f6afbe97e9e96c12efef66fb4b65a0a3d": 0,
+		"de6e4f2": 0,
+		"unknown": 1,
+		"de6e4f":  1,
+		"":        0,
+	}
+
+	for hash, expect := range tests {
+		vconf := testConfig()
+		vconf.GitHash = hash
+		v, err := NewVersion(&vconf)
+		if err != nil {
+			t.Error(err)
+		}
+		if len(v.Warnings()) != expect {
+			t.Errorf("Expected %d warnings for %q, got %v", expect, hash, v.Warnings())
+		}
+	}
+}
+
+func TestNewVersionInvalid(t *testing.T) {
+	vconf := testConfig()
+	vconf.VersionString = "1.2"
+
+	_, err := NewVersion(&vconf)
+	if !errors.Is(err, ErrInvalidVersion) {
+		t.Errorf("Expected an error wrapping ErrInvalidVersion, got %v", err)
+	}
+}
+
+func TestTimestampStrict(t *testing.T) {
+	vconf := testConfig()
+	vconf.TStamp = "yesterday"
+
+	if _, err := NewVersion(&vconf); err == nil {
+		t.Error("Expected an error for a malformed timestamp")
+	}
+}
+
+func TestTimestampTolerant(t *testing.T) {
+	expect := "The timestamp \"yesterday\" could not be parsed and has been ignored."
+
+	vconf := testConfig()
+	vconf.TStamp = "yesterday"
+	vconf.TimestampTolerant = true
+
+	v, err := NewVersion(&vconf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.TStampUnix() != 0 {
+		t.Errorf("Expected an unset timestamp, got %s", v.TStamp())
+	}
+	if len(v.Warnings()) != 1 || v.Warnings()[0] != expect {
+		t.Errorf("Expected [%s], got %v", expect, v.Warnings())
+	}
+}
+
+func TestIsSnapshot(t *testing.T) {
+	tests := []struct {
+		version, release string
+		expect           bool
+	}{
+		{"1.2.3", "prod", false},
+		{"1.2.3", "production", false},
+		{"1.2.3-rc.1", "prod", true},
+		{"1.2.3", "test", true},
+		{"1.2.3-2-ga1b2c3d", "test", true},
+	}
+
+	for _, test := range tests {
+		vconf := testConfig()
+		vconf.VersionString = test.version
+		vconf.Release = test.release
+		v, err := NewVersion(&vconf)
+		if err != nil {
+			t.Error(err)
+		}
+		if v.IsSnapshot() != test.expect {
+			t.Errorf("Expected IsSnapshot for %s (%s) to be %t", test.version, test.release, test.expect)
+		}
+	}
+}
+
+func TestReleaseNormalization(t *testing.T) {
+	tests := []struct {
+		release       string
+		caseSensitive bool
+		expect        bool
+	}{
+		{"prod", false, true},
+		{" prod ", false, true},
+		{"Prod", false, true},
+		{"PRODUCTION\n", false, true},
+		{"prod", true, true},
+		{" prod ", true, true},
+		{"Prod", true, false},
+		{"test", false, false},
+	}
+
+	for _, test := range tests {
+		vconf := testConfig()
+		vconf.Release = test.release
+		vconf.ReleaseCaseSensitive = test.caseSensitive
+		v, err := NewVersion(&vconf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v.IsSnapshot() == test.expect {
+			t.Errorf("Expected %q (case-sensitive %t) to be production: %t", test.release, test.caseSensitive, test.expect)
+		}
+		if v.Release() != strings.TrimSpace(test.release) {
+			t.Errorf("Expected the release label to be trimmed, got %q", v.Release())
+		}
+	}
+}
+
+func TestReleaseNormalizationBranchReleases(t *testing.T) {
+	vconf := testConfig()
+	vconf.GitBranch = "master"
+	vconf.Release = " Prod"
+	vconf.BranchReleases = map[string][]string{"master": {"prod"}}
+
+	v, err := NewVersion(&vconf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(v.Warnings()) != 0 {
+		t.Errorf("Expected no warnings, got %v", v.Warnings())
+	}
+
+	vconf.ReleaseCaseSensitive = true
+	v, err = NewVersion(&vconf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(v.Warnings()) != 2 {
+		t.Errorf("Expected non-production and branch warnings, got %v", v.Warnings())
+	}
+}
+
+func TestValidators(t *testing.T) {
+	called := 0
+	vconf := testConfig()
+	vconf.Release = "test"
+	vconf.Validators = []func(Version) error{
+		func(v Version) error {
+			called++
+			if v.Patch() >= 100 {
+				return errors.New("patch must be below 100")
+			}
+			return nil
+		},
+		nil,
+	}
+
+	v, err := NewVersion(&vconf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if called != 1 {
+		t.Errorf("Expected the validator to be called once, got %d", called)
+	}
+	if len(v.Warnings()) != 1 {
+		t.Errorf("Expected the built-in warnings to still apply, got %v", v.Warnings())
+	}
+}
+
+func TestValidatorsFailing(t *testing.T) {
+	errPatch := errors.New("patch must be below 100")
+	errBranch := errors.New("must be built from master")
+
+	vconf := testConfig()
+	vconf.VersionString = "1.2.300"
+	vconf.Validators = []func(Version) error{
+		func(v Version) error {
+			if v.Patch() >= 100 {
+				return errPatch
+			}
+			return nil
+		},
+		func(v Version) error { return nil },
+		func(v Version) error {
+			if v.GitBranch() != "master" {
+				return errBranch
+			}
+			return nil
+		},
+	}
+
+	_, err := NewVersion(&vconf)
+	if err == nil {
+		t.Fatal("Expected a validation error")
+	}
+	if !errors.Is(err, errPatch) || !errors.Is(err, errBranch) {
+		t.Errorf("Expected both validator errors, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "1.2.300") {
+		t.Errorf("Expected the error to name the version, got %v", err)
+	}
+}
+
+func TestIsTaggedRelease(t *testing.T) {
+	tests := map[string]bool{
+		"1.2.3":                 true,
+		"1.2.3-rc.1":            true,
+		"1.2.3-2-ga1b2c3d":      false,
+		"1.2.3-15-g1234abc":     false,
+		"1.2.3-ga1b2c3d":        true,
+		"1.2.3-rc.1-2-gabc1234": false,
+		"1.2.3-beta-5-g1234abc": false,
+	}
+
+	for version, expect := range tests {
+		vconf := testConfig()
+		vconf.VersionString = version
+		v, err := NewVersion(&vconf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v.IsTaggedRelease() != expect {
+			t.Errorf("Expected IsTaggedRelease for %s to be %t", version, expect)
+		}
+	}
+}
+
+func TestEmptyVersionStrict(t *testing.T) {
+	vconf := testConfig()
+	vconf.VersionString = ""
+
+	if _, err := NewVersion(&vconf); err != ErrEmptyVersion {
+		t.Errorf("Expected ErrEmptyVersion, got %v", err)
+	}
+}
+
+func TestEmptyVersionTolerant(t *testing.T) {
+	expect := "0.0.0"
+	expectWarning := "No version string was set at compile time. Defaulting to 0.0.0."
+
+	vconf := testConfig()
+	vconf.VersionString = ""
+	vconf.VersionTolerant = true
+
+	v, err := NewVersion(&vconf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Semver() != expect {
+		t.Errorf("Expected %s, got %s", expect, v.Semver())
+	}
+	if len(v.Warnings()) != 1 || v.Warnings()[0] != expectWarning {
+		t.Errorf("Expected [%s], got %v", expectWarning, v.Warnings())
+	}
+}
+
+func TestIsDetached(t *testing.T) {
+	tests := map[string]bool{
+		"":       true,
+		"HEAD":   true,
+		"master": false,
+	}
+
+	for branch, expect := range tests {
+		vconf := testConfig()
+		vconf.GitBranch = branch
+		v, err := NewVersion(&vconf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v.IsDetached() != expect {
+			t.Errorf("Expected IsDetached for %q to be %t", branch, expect)
+		}
+
+		warned := false
+		for _, w := range v.StructuredWarnings() {
+			if w.Code == WarningDetachedHead {
+				warned = true
+			}
+		}
+		if warned != expect {
+			t.Errorf("Expected a detached HEAD warning for %q to be %t, got %v", branch, expect, v.Warnings())
+		}
+	}
+}
+
+func TestVersionStringWhitespace(t *testing.T) {
+	expect := "1.2.3"
+
+	for _, s := range []string{"1.2.3\n", " 1.2.3 ", "1.2.3"} {
+		vconf := testConfig()
+		vconf.VersionString = s
+		v, err := NewVersion(&vconf)
+		if err != nil {
+			t.Errorf("Expected %q to parse, got %s", s, err)
+			continue
+		}
+		if v.Semver() != expect {
+			t.Errorf("Expected %s, got %s", expect, v.Semver())
+		}
+	}
+
+	vconf := testConfig()
+	vconf.VersionString = " \n"
+	if _, err := NewVersion(&vconf); err != ErrEmptyVersion {
+		t.Errorf("Expected ErrEmptyVersion for a blank version string, got %v", err)
+	}
+}
+
+func TestTimestampParser(t *testing.T) {
+	expect := "2019-02-14T15:04:05Z"
+
+	vconf := testConfig()
+	vconf.TStamp = "20190214.150405"
+	vconf.TimestampParser = func(s string) (time.Time, error) {
+		return time.Parse("20060102.150405", s)
+	}
+
+	v, err := NewVersion(&vconf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.TStamp() != expect {
+		t.Errorf("Expected %s, got %s", expect, v.TStamp())
+	}
+
+	vconf.TStamp = "Thu Feb 14 15:04:05 UTC 2019"
+	if _, err := NewVersion(&vconf); err == nil {
+		t.Error("Expected the custom parser to reject the default layout")
+	}
+}
+
+func TestTimestampParserDefault(t *testing.T) {
+	expect := "2019-02-14T15:04:05Z"
+
+	vconf := testConfig()
+	v, err := NewVersion(&vconf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.TStamp() != expect {
+		t.Errorf("Expected %s, got %s", expect, v.TStamp())
+	}
+}
+
+func TestTimestampFormats(t *testing.T) {
+	expect := "2019-02-14T13:04:05Z"
+
+	tests := []string{
+		"Thu Feb 14 13:04:05 UTC 2019", // date
+		"2019-02-14T15:04:05+02:00",    // git show -s --format=%cI
+		"1550149445",                   // git show -s --format=%ct
+	}
+
+	for _, tstamp := range tests {
+		vconf := testConfig()
+		vconf.TStamp = tstamp
+		v, err := NewVersion(&vconf)
+		if err != nil {
+			t.Errorf("Expected %q to parse, got %s", tstamp, err)
+			continue
+		}
+		if v.timestamp.UTC().Format(time.RFC3339) != expect {
+			t.Errorf("Expected %s for %q, got %s", expect, tstamp, v.TStamp())
+		}
+	}
+}
+
+func TestTimestampInvalid(t *testing.T) {
+	vconf := testConfig()
+	vconf.TStamp = "14/02/2019"
+
+	_, err := NewVersion(&vconf)
+	if !errors.Is(err, ErrInvalidTimestamp) {
+		t.Errorf("Expected ErrInvalidTimestamp, got %v", err)
+	}
+}
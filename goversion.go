@@ -0,0 +1,78 @@
+package govee
+
+import (
+	"strconv"
+	"strings"
+)
+
+// goVersion is a Go toolchain release such as go1.20.3 or go1.21rc2.
+type goVersion struct {
+	major, minor, patch int
+	pre                 bool // a beta or release candidate.
+}
+
+// parseGoVersion parses a toolchain version in the goX, goX.Y or goX.Y.Z form
+// reported by runtime.Version, optionally followed by a pre-release such as
+// rc2 or beta1. Anything after the first space, such as an experiment list,
+// is ignored.
+func parseGoVersion(s string) (goVersion, bool) {
+	var g goVersion
+	if i := strings.IndexByte(s, ' '); i >= 0 {
+		s = s[:i]
+	}
+	if !strings.HasPrefix(s, "go") {
+		return g, false
+	}
+	s = s[2:]
+	if i := strings.IndexAny(s, "abcdefghijklmnopqrstuvwxyz"); i >= 0 {
+		g.pre = true
+		s = s[:i]
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) > 3 {
+		return g, false
+	}
+	nums := []*int{&g.major, &g.minor, &g.patch}
+	for i, p := range parts {
+		if p == "" || strings.TrimLeft(p, "0123456789") != "" {
+			return g, false
+		}
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return g, false
+		}
+		*nums[i] = n
+	}
+	return g, true
+}
+
+// less reports whether g is an older release than other. A pre-release is
+// older than the release it precedes.
+func (g goVersion) less(other goVersion) bool {
+	if g.major != other.major {
+		return g.major < other.major
+	}
+	if g.minor != other.minor {
+		return g.minor < other.minor
+	}
+	if g.patch != other.patch {
+		return g.patch < other.patch
+	}
+	return g.pre && !other.pre
+}
+
+// olderGo reports whether the compiler is a Go release older than the
+// configured minimum. A compiler that isn't a recognisable Go release, such
+// as gccgo, is not reported.
+func (v Version) olderGo() bool {
+	if v.mingo == "" {
+		return false
+	}
+	min, ok := parseGoVersion(v.mingo)
+	if !ok {
+		return false
+	}
+	g, ok := parseGoVersion(v.compiler)
+	return ok && g.less(min)
+}
@@ -0,0 +1,79 @@
+package govee
+
+import "testing"
+
+func TestParseGoVersion(t *testing.T) {
+	tests := []struct {
+		s      string
+		expect goVersion
+		ok     bool
+	}{
+		{"go1", goVersion{1, 0, 0, false}, true},
+		{"go1.20", goVersion{1, 20, 0, false}, true},
+		{"go1.20.3", goVersion{1, 20, 3, false}, true},
+		{"go1.21rc2", goVersion{1, 21, 0, true}, true},
+		{"go1.9beta1", goVersion{1, 9, 0, true}, true},
+		{"go1.22.1 X:boringcrypto", goVersion{1, 22, 1, false}, true},
+		{"gccgo", goVersion{}, false},
+		{"1.20", goVersion{}, false},
+		{"go1.20.3.1", goVersion{}, false},
+		{"go1..3", goVersion{}, false},
+		{"go1.x", goVersion{}, false},
+	}
+
+	for _, test := range tests {
+		g, ok := parseGoVersion(test.s)
+		if ok != test.ok || (ok && g != test.expect) {
+			t.Errorf("Expected %+v, %t for %q, got %+v, %t", test.expect, test.ok, test.s, g, ok)
+		}
+	}
+}
+
+func TestMinGoVersion(t *testing.T) {
+	tests := []struct {
+		compiler, min string
+		expect        bool
+	}{
+		{"go1.11.1", "go1.20", true},
+		{"go1.19.13", "go1.20", true},
+		{"go1.20rc1", "go1.20", true},
+		{"go1.20", "go1.20", false},
+		{"go1.20.3", "go1.20", false},
+		{"go1.21.0", "go1.20", false},
+		{"go1.21.0", "go1.21.1", true},
+		{"gc", "go1.20", false},
+		{"go1.11.1", "", false},
+	}
+
+	for _, test := range tests {
+		vconf := testConfig()
+		vconf.Compiler = test.compiler
+		vconf.MinGoVersion = test.min
+		v, err := NewVersion(&vconf)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		warned := false
+		for _, w := range v.StructuredWarnings() {
+			if w.Code == WarningOldGoVersion {
+				warned = true
+				if w.Severity != SeverityCritical {
+					t.Errorf("Expected a critical warning, got %s", w.Severity)
+				}
+			}
+		}
+		if warned != test.expect {
+			t.Errorf("Expected warning %t for %s with minimum %q, got %v", test.expect, test.compiler, test.min, v.Warnings())
+		}
+	}
+}
+
+func TestMinGoVersionInvalid(t *testing.T) {
+	vconf := testConfig()
+	vconf.MinGoVersion = "1.20"
+
+	if _, err := NewVersion(&vconf); err == nil {
+		t.Error("Expected an error for an invalid minimum Go version")
+	}
+}
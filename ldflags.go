@@ -0,0 +1,111 @@
+package govee
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ldflagFields maps the variable names set with -X, as used in the README's
+// build script, to the VersionConfig field they populate.
+var ldflagFields = map[string]func(c *VersionConfig) *string{
+	"VString":       func(c *VersionConfig) *string { return &c.VersionString },
+	"VersionString": func(c *VersionConfig) *string { return &c.VersionString },
+	"GitHash":       func(c *VersionConfig) *string { return &c.GitHash },
+	"GitBranch":     func(c *VersionConfig) *string { return &c.GitBranch },
+	"GitUser":       func(c *VersionConfig) *string { return &c.GitUser },
+	"GitTag":        func(c *VersionConfig) *string { return &c.GitTag },
+	"OS":            func(c *VersionConfig) *string { return &c.OS },
+	"Arch":          func(c *VersionConfig) *string { return &c.Arch },
+	"Compiler":      func(c *VersionConfig) *string { return &c.Compiler },
+	"Release":       func(c *VersionConfig) *string { return &c.Release },
+	"TStamp":        func(c *VersionConfig) *string { return &c.TStamp },
+	"CIProvider":    func(c *VersionConfig) *string { return &c.CIProvider },
+	"CIRunID":       func(c *VersionConfig) *string { return &c.CIRunID },
+	"CIRunURL":      func(c *VersionConfig) *string { return &c.CIRunURL },
+}
+
+// ParseLDFlags reads the -X pkg.Var=value definitions from a recorded
+// -ldflags string into a VersionConfig. Variables are matched by name,
+// regardless of package, and unrelated flags and variables are ignored.
+// Values may be quoted with single or double quotes.
+func ParseLDFlags(s string) (*VersionConfig, error) {
+	args, err := splitFlags(s)
+	if err != nil {
+		return nil, err
+	}
+
+	c := VersionConfig{}
+	for i := 0; i < len(args); i++ {
+		var def string
+		switch {
+		case args[i] == "-X" || args[i] == "--X":
+			if i+1 == len(args) {
+				return nil, fmt.Errorf("govee: flag %s needs an argument", args[i])
+			}
+			i++
+			def = args[i]
+		case strings.HasPrefix(args[i], "-X="):
+			def = strings.TrimPrefix(args[i], "-X=")
+		case strings.HasPrefix(args[i], "--X="):
+			def = strings.TrimPrefix(args[i], "--X=")
+		default:
+			continue
+		}
+
+		eq := strings.Index(def, "=")
+		if eq < 0 {
+			return nil, fmt.Errorf("govee: invalid -X definition %q: expected pkg.Var=value", def)
+		}
+		name := def[:eq]
+		if dot := strings.LastIndex(name, "."); dot >= 0 {
+			name = name[dot+1:]
+		}
+		if field, ok := ldflagFields[name]; ok {
+			*field(&c) = def[eq+1:]
+		}
+	}
+	return &c, nil
+}
+
+// splitFlags splits s into arguments the way a shell would, honouring single
+// quotes, double quotes and backslash escapes.
+func splitFlags(s string) ([]string, error) {
+	var args []string
+	var arg strings.Builder
+	var quote rune
+	inArg, escaped := false, false
+
+	for _, r := range s {
+		switch {
+		case escaped:
+			arg.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped, inArg = true, true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				arg.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inArg = r, true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		default:
+			arg.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 || escaped {
+		return nil, fmt.Errorf("govee: unterminated quote or escape in %q", s)
+	}
+	if inArg {
+		args = append(args, arg.String())
+	}
+	return args, nil
+}
@@ -0,0 +1,43 @@
+package govee
+
+import "testing"
+
+func TestParseLDFlags(t *testing.T) {
+	flags := `-s -w -X main.VString=1.2.3 -X "main.GitHash=1234567890abcdef" ` +
+		`-X 'main.GitUser=Jane Doe' -X=main.OS=linux -X main.TStamp="Thu Feb 14 15:04:05 UTC 2019" ` +
+		`-X main.Unrelated=ignored`
+
+	c, err := ParseLDFlags(flags)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect := VersionConfig{
+		VersionString: "1.2.3",
+		GitHash:       "1234567890abcdef",
+		GitUser:       "Jane Doe",
+		OS:            "linux",
+		TStamp:        "Thu Feb 14 15:04:05 UTC 2019",
+	}
+	if !c.Equal(&expect) {
+		t.Errorf("Expected %#v, got %#v", expect, *c)
+	}
+
+	if _, err := NewVersion(c.Merge(&VersionConfig{Release: "prod"})); err != nil {
+		t.Errorf("Expected parsed config to construct a version, got %s", err)
+	}
+}
+
+func TestParseLDFlagsMalformed(t *testing.T) {
+	tests := []string{
+		"-X main.VString=1.2.3 -X",
+		"-X main.VString",
+		`-X "main.GitUser=Jane Doe`,
+	}
+
+	for _, flags := range tests {
+		if _, err := ParseLDFlags(flags); err == nil {
+			t.Errorf("Expected an error for %q", flags)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+module github.com/prinsmike/govee/otelgovee
+
+go 1.21
+
+require (
+	github.com/prinsmike/govee v0.1.0
+	go.opentelemetry.io/otel v1.28.0
+)
+
+require github.com/blang/semver v3.5.1+incompatible // indirect
+
+// Build against the enclosing checkout during development. Consumers ignore
+// this and use the required release.
+replace github.com/prinsmike/govee => ../
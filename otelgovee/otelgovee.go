@@ -0,0 +1,64 @@
+// Package otelgovee maps govee version information to OpenTelemetry resource
+// attributes. It is a separate module so that the core govee package doesn't
+// depend on OpenTelemetry.
+package otelgovee
+
+import (
+	"github.com/prinsmike/govee"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ResourceAttributes returns the version information as OpenTelemetry
+// resource attributes, using the semantic convention keys service.version,
+// vcs.repository.ref.revision, vcs.repository.ref.name,
+// vcs.repository.ref.type, os.type, host.arch and process.runtime.version.
+// GOOS and GOARCH values are translated to the semantic convention values
+// where they differ, such as x86 for 386. Attributes for empty fields are
+// omitted.
+func ResourceAttributes(v govee.Version) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		attribute.String("service.version", v.Semver()),
+	}
+	if v.GitHash() != "" {
+		attrs = append(attrs, attribute.String("vcs.repository.ref.revision", v.GitHash()))
+	}
+	if v.GitBranch() != "" && !v.IsDetached() {
+		attrs = append(attrs,
+			attribute.String("vcs.repository.ref.name", v.GitBranch()),
+			attribute.String("vcs.repository.ref.type", "branch"),
+		)
+	}
+	if v.OS() != "" {
+		attrs = append(attrs, attribute.String("os.type", semconvValue(osTypes, v.OS())))
+	}
+	if v.Arch() != "" {
+		attrs = append(attrs, attribute.String("host.arch", semconvValue(hostArchs, v.Arch())))
+	}
+	if v.Compiler() != "" {
+		attrs = append(attrs, attribute.String("process.runtime.version", v.Compiler()))
+	}
+	return attrs
+}
+
+// osTypes and hostArchs map the GOOS and GOARCH values that differ from the
+// os.type and host.arch semantic convention values.
+var (
+	osTypes = map[string]string{
+		"dragonfly": "dragonflybsd",
+		"zos":       "z_os",
+	}
+	hostArchs = map[string]string{
+		"386": "x86",
+		"arm": "arm32",
+		"ppc": "ppc32",
+	}
+)
+
+// semconvValue returns the semantic convention value for s from m, or s
+// itself if it needs no translation.
+func semconvValue(m map[string]string, s string) string {
+	if v, ok := m[s]; ok {
+		return v
+	}
+	return s
+}
@@ -0,0 +1,94 @@
+package otelgovee
+
+import (
+	"testing"
+
+	"github.com/prinsmike/govee"
+)
+
+func TestResourceAttributes(t *testing.T) {
+	expect := []struct{ key, value string }{
+		{"service.version", "1.2.3"},
+		{"vcs.repository.ref.revision", "1234567890abcdef"},
+		{"vcs.repository.ref.name", "testing"},
+		{"vcs.repository.ref.type", "branch"},
+		{"os.type", "linux"},
+		{"host.arch", "amd64"},
+		{"process.runtime.version", "go1.11.1"},
+	}
+
+	vconf := govee.VersionConfig{
+		VersionString: "1.2.3",
+		GitHash:       "1234567890abcdef",
+		GitBranch:     "testing",
+		GitUser:       "Jane Doe",
+		OS:            "linux",
+		Arch:          "amd64",
+		Compiler:      "go1.11.1",
+		Release:       "prod",
+		TStamp:        "Thu Feb 14 15:04:05 UTC 2019",
+	}
+	v, err := govee.NewVersion(&vconf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	attrs := ResourceAttributes(v)
+	if len(attrs) != len(expect) {
+		t.Fatalf("Expected %d attributes, got %d: %v", len(expect), len(attrs), attrs)
+	}
+	for i := range expect {
+		if string(attrs[i].Key) != expect[i].key || attrs[i].Value.AsString() != expect[i].value {
+			t.Errorf("Expected %s=%s, got %s=%s", expect[i].key, expect[i].value, attrs[i].Key, attrs[i].Value.AsString())
+		}
+	}
+}
+
+func TestResourceAttributesMinimal(t *testing.T) {
+	v, err := govee.ParseVersionString("1.2.3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	attrs := ResourceAttributes(v)
+	if len(attrs) != 1 || attrs[0].Key != "service.version" {
+		t.Errorf("Expected only service.version, got %v", attrs)
+	}
+}
+
+func TestResourceAttributesSemconvValues(t *testing.T) {
+	tests := []struct {
+		goos, goarch     string
+		osType, hostArch string
+	}{
+		{"linux", "amd64", "linux", "amd64"},
+		{"windows", "386", "windows", "x86"},
+		{"linux", "arm", "linux", "arm32"},
+		{"darwin", "arm64", "darwin", "arm64"},
+		{"dragonfly", "amd64", "dragonflybsd", "amd64"},
+		{"zos", "s390x", "z_os", "s390x"},
+		{"aix", "ppc64", "aix", "ppc64"},
+	}
+
+	for _, test := range tests {
+		vconf := govee.VersionConfig{
+			VersionString: "1.2.3",
+			OS:            test.goos,
+			Arch:          test.goarch,
+			Release:       "prod",
+			TStamp:        "Thu Feb 14 15:04:05 UTC 2019",
+		}
+		v, err := govee.NewVersion(&vconf)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got := map[string]string{}
+		for _, attr := range ResourceAttributes(v) {
+			got[string(attr.Key)] = attr.Value.AsString()
+		}
+		if got["os.type"] != test.osType || got["host.arch"] != test.hostArch {
+			t.Errorf("Expected %s/%s for %s/%s, got %s/%s", test.osType, test.hostArch, test.goos, test.goarch, got["os.type"], got["host.arch"])
+		}
+	}
+}
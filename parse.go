@@ -0,0 +1,282 @@
+package govee
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/blang/semver"
+)
+
+// ErrInvalidVersion is returned, wrapped, when a version string can't be
+// parsed as a semantic version.
+var ErrInvalidVersion = errors.New("govee: invalid version string")
+
+// ErrInvalidTimestamp is returned, wrapped, when a timestamp can't be parsed.
+var ErrInvalidTimestamp = errors.New("govee: invalid timestamp")
+
+// ErrEmptyVersion is returned when the version string is empty, which usually
+// means it wasn't set at compile time.
+var ErrEmptyVersion = errors.New("govee: empty version string")
+
+// MaxComponent is the largest supported major, minor or patch number, so
+// that every component fits in an int64 and, on 64-bit platforms, in the int
+// returned by Major, Minor and Patch.
+const MaxComponent = math.MaxInt64
+
+// ComponentRangeError is returned when a major, minor or patch number is
+// larger than MaxComponent. It unwraps to ErrInvalidVersion.
+type ComponentRangeError struct {
+	Component string // "major", "minor" or "patch".
+	Value     string
+}
+
+func (e *ComponentRangeError) Error() string {
+	return fmt.Sprintf("govee: %s version %s is out of range, the maximum is %d", e.Component, e.Value, uint64(MaxComponent))
+}
+
+// Unwrap returns ErrInvalidVersion.
+func (e *ComponentRangeError) Unwrap() error {
+	return ErrInvalidVersion
+}
+
+// checkComponents returns a *ComponentRangeError if a numeric major, minor or
+// patch number in the version string s exceeds MaxComponent. Other problems
+// are left to the semver parser.
+func checkComponents(s string) error {
+	if i := strings.IndexAny(s, "-+"); i >= 0 {
+		s = s[:i]
+	}
+	for i, part := range strings.SplitN(s, ".", 3) {
+		if part == "" || strings.TrimLeft(part, "0123456789") != "" {
+			continue
+		}
+		if n, err := strconv.ParseUint(part, 10, 64); err != nil || n > MaxComponent {
+			return &ComponentRangeError{Component: []string{"major", "minor", "patch"}[i], Value: part}
+		}
+	}
+	return nil
+}
+
+// ParseVersionString creates a new version from a semver string alone,
+// ignoring surrounding whitespace, including the carriage returns of Windows
+// line endings. The git, platform, release and timestamp fields are left
+// empty. A major, minor or patch number above MaxComponent returns a
+// *ComponentRangeError.
+func ParseVersionString(s string) (Version, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Version{}, ErrEmptyVersion
+	}
+	if err := checkComponents(s); err != nil {
+		return Version{}, err
+	}
+	sv, err := semver.Make(s)
+	if err != nil {
+		return Version{}, fmt.Errorf("%w: %s", ErrInvalidVersion, err)
+	}
+
+	v := Version{semver: sv}
+	v.setWarnings()
+	return v, nil
+}
+
+// FromFile creates a new version from a file, such as VERSION, that holds
+// only a version string, parsed with ParseVersionString. Errors reading
+// the file are returned wrapped as is, and errors parsing its content are
+// those of ParseVersionString, such as ErrEmptyVersion for an empty file or
+// ErrInvalidVersion for a malformed one.
+func FromFile(path string) (Version, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return Version{}, fmt.Errorf("govee: reading version file: %w", err)
+	}
+
+	v, err := ParseVersionString(string(b))
+	if err != nil {
+		return Version{}, fmt.Errorf("govee: parsing version file %s: %w", path, err)
+	}
+	return v, nil
+}
+
+// ParseLines parses one version string per line from r, such as a list of
+// git tags, using ParseVersionString. Blank lines and lines starting with "#"
+// are skipped. It returns the versions that parsed, in order, and an error
+// for each line that didn't, naming the line number. An error reading r is
+// added last.
+func ParseLines(r io.Reader) ([]Version, []error) {
+	var vs []Version
+	var errs []error
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		v, err := ParseVersionString(line)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("govee: line %d: %w", n, err))
+			continue
+		}
+		vs = append(vs, v)
+	}
+	if err := sc.Err(); err != nil {
+		errs = append(errs, fmt.Errorf("govee: reading versions: %w", err))
+	}
+	return vs, errs
+}
+
+// DecodeFrom creates a new version from the JSON form produced by
+// Version.MarshalJSON, read from r. The stream must hold a single JSON object
+// and nothing but whitespace after it. Warnings in the document are ignored
+// and recomputed.
+func DecodeFrom(r io.Reader) (Version, error) {
+	dec := json.NewDecoder(r)
+
+	var j versionJSON
+	if err := dec.Decode(&j); err != nil {
+		return Version{}, fmt.Errorf("govee: decoding version: %w", err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return Version{}, errors.New("govee: decoding version: unexpected data after JSON object")
+	}
+
+	v, err := ParseVersionString(j.Version)
+	if err != nil {
+		return Version{}, err
+	}
+	v.githash = j.GitHash
+	v.gitbranch = j.GitBranch
+	v.gituser = j.GitUser
+	v.gittag = j.GitTag
+	v.os = j.OS
+	v.arch = j.Arch
+	v.compiler = j.Compiler
+	v.release = strings.TrimSpace(j.Release)
+	v.ciprovider = j.CIProvider
+	v.cirunid = j.CIRunID
+	v.cirunurl = j.CIRunURL
+	if j.Timestamp != "" {
+		v.timestamp, err = time.Parse(time.RFC3339, j.Timestamp)
+		if err != nil {
+			return Version{}, fmt.Errorf("govee: decoding version: %w", err)
+		}
+	}
+	v.setWarnings()
+	return v, nil
+}
+
+// Standard OCI image annotation keys read by FromOCILabels.
+const (
+	OCILabelVersion  = "org.opencontainers.image.version"
+	OCILabelRevision = "org.opencontainers.image.revision"
+	OCILabelCreated  = "org.opencontainers.image.created"
+)
+
+// FromOCILabels creates a new version from OCI image labels. The version
+// label is required; the revision label sets the git hash and the created
+// label, an RFC 3339 timestamp, sets the timestamp. Either may be missing.
+// The labels carry no git branch, so the revision isn't reported as a
+// detached HEAD.
+func FromOCILabels(labels map[string]string) (Version, error) {
+	v, err := ParseVersionString(labels[OCILabelVersion])
+	if err != nil {
+		return Version{}, err
+	}
+
+	v.githash = labels[OCILabelRevision]
+	v.nobranch = true
+	if created := labels[OCILabelCreated]; created != "" {
+		v.timestamp, err = time.Parse(time.RFC3339, created)
+		if err != nil {
+			return Version{}, fmt.Errorf("govee: invalid %s label: %w", OCILabelCreated, err)
+		}
+	}
+	v.setWarnings()
+	return v, nil
+}
+
+// FromGoVersionM creates a new version from the output of "go version -m"
+// for a single binary. The version comes from the main module, the compiler
+// from the Go version on the first line, and the git hash, timestamp, OS and
+// architecture from the vcs.revision, vcs.time, GOOS and GOARCH build
+// settings. vcs.modified is ignored. A binary built without VCS stamping gets
+// a warning instead of a git hash and timestamp, and one whose main module
+// has no version, such as "(devel)", is treated as 0.0.0 with a warning.
+func FromGoVersionM(output string) (Version, error) {
+	var v Version
+	var compiler, module string
+	settings := map[string]string{}
+	for i, line := range strings.Split(output, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if i == 0 {
+			if j := strings.LastIndex(line, ": "); j >= 0 {
+				compiler = strings.TrimSpace(line[j+2:])
+			}
+			continue
+		}
+		fields := strings.Split(strings.TrimSpace(line), "\t")
+		switch {
+		case len(fields) >= 3 && fields[0] == "mod" && module == "":
+			module = fields[2]
+		case len(fields) >= 2 && fields[0] == "build":
+			if kv := strings.SplitN(fields[1], "=", 2); len(kv) == 2 {
+				settings[kv[0]] = kv[1]
+			}
+		}
+	}
+	if compiler == "" || !strings.HasPrefix(compiler, "go") {
+		return Version{}, errors.New("govee: no Go version in go version -m output")
+	}
+
+	if module == "" || module == "(devel)" {
+		v.noversion = true
+	} else {
+		var err error
+		v, err = ParseVersionString(strings.TrimPrefix(module, "v"))
+		if err != nil {
+			return Version{}, err
+		}
+	}
+	v.compiler = compiler
+	v.os = settings["GOOS"]
+	v.arch = settings["GOARCH"]
+	v.githash = settings["vcs.revision"]
+	v.novcs = v.githash == ""
+	v.nobranch = true
+	if t := settings["vcs.time"]; t != "" {
+		ts, err := time.Parse(time.RFC3339, t)
+		if err != nil {
+			return Version{}, fmt.Errorf("govee: invalid vcs.time setting: %w", err)
+		}
+		v.timestamp = ts
+	}
+	v.setWarnings()
+	return v, nil
+}
+
+// versionPattern matches a semantic version, optionally prefixed with "v",
+// within arbitrary text.
+var versionPattern = regexp.MustCompile(
+	`\bv?((?:0|[1-9][0-9]*)\.(?:0|[1-9][0-9]*)\.(?:0|[1-9][0-9]*)` +
+		`(?:-[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*)?(?:\+[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*)?)`,
+)
+
+// ExtractVersion creates a new version from the first semantic version found
+// in text, such as the output of "myapp --version". A leading "v" is
+// ignored. If text contains no version, the error wraps ErrInvalidVersion.
+func ExtractVersion(text string) (Version, error) {
+	m := versionPattern.FindStringSubmatch(text)
+	if m == nil {
+		return Version{}, fmt.Errorf("%w: no version found in %q", ErrInvalidVersion, text)
+	}
+	return ParseVersionString(m[1])
+}
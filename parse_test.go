@@ -0,0 +1,389 @@
+package govee
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeVersionFile writes content to a VERSION file in a temporary directory
+// and returns its path.
+func writeVersionFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "VERSION")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestParseVersionString(t *testing.T) {
+	expect := "1.2.3-rc.1"
+
+	v, err := ParseVersionString("1.2.3-rc.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Semver() != expect {
+		t.Errorf("Expected %s, got %s", expect, v.Semver())
+	}
+	if v.GitHash() != "" || v.TStampUnix() != 0 {
+		t.Errorf("Expected empty git and timestamp fields, got %#v", v)
+	}
+
+	if _, err := ParseVersionString("1.2"); !errors.Is(err, ErrInvalidVersion) {
+		t.Errorf("Expected ErrInvalidVersion, got %v", err)
+	}
+}
+
+func TestFromFile(t *testing.T) {
+	expect := "1.2.3"
+
+	v, err := FromFile(writeVersionFile(t, "  1.2.3\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Semver() != expect {
+		t.Errorf("Expected %s, got %s", expect, v.Semver())
+	}
+}
+
+func TestFromFileCRLF(t *testing.T) {
+	expect := "1.2.3"
+
+	for _, content := range []string{"1.2.3\r\n", "1.2.3\r", "\r\n1.2.3\r\n\r\n"} {
+		v, err := FromFile(writeVersionFile(t, content))
+		if err != nil {
+			t.Errorf("Expected %q to parse, got %s", content, err)
+			continue
+		}
+		if v.Semver() != expect {
+			t.Errorf("Expected %s, got %s", expect, v.Semver())
+		}
+	}
+}
+
+func TestFromFileInvalid(t *testing.T) {
+	_, err := FromFile(writeVersionFile(t, "not a version\n"))
+	if !errors.Is(err, ErrInvalidVersion) {
+		t.Errorf("Expected ErrInvalidVersion, got %v", err)
+	}
+}
+
+func TestFromFileEmpty(t *testing.T) {
+	_, err := FromFile(writeVersionFile(t, "\n"))
+	if !errors.Is(err, ErrEmptyVersion) {
+		t.Errorf("Expected ErrEmptyVersion, got %v", err)
+	}
+}
+
+func TestFromFileMissing(t *testing.T) {
+	_, err := FromFile(filepath.Join(t.TempDir(), "VERSION"))
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected os.ErrNotExist, got %v", err)
+	}
+	if errors.Is(err, ErrInvalidVersion) {
+		t.Errorf("Expected an I/O error, got %v", err)
+	}
+}
+
+func TestDecodeFrom(t *testing.T) {
+	expect := testVersion(t, "1.2.3-rc.1")
+
+	b, err := json.Marshal(expect)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := DecodeFrom(bytes.NewReader(append(b, '\n')))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !v.EqualFull(expect) {
+		t.Errorf("Expected %#v, got %#v", expect, v)
+	}
+}
+
+func TestDecodeFromTrailingData(t *testing.T) {
+	r := strings.NewReader(`{"version":"1.2.3"} {"version":"2.0.0"}`)
+
+	if _, err := DecodeFrom(r); err == nil {
+		t.Error("Expected an error for trailing data")
+	}
+
+	r = strings.NewReader(`{"version":"1.2.3"}garbage`)
+	if _, err := DecodeFrom(r); err == nil {
+		t.Error("Expected an error for trailing garbage")
+	}
+}
+
+func TestParseLines(t *testing.T) {
+	input := "# release tags\n" +
+		"1.0.0\n" +
+		"\n" +
+		"  1.1.0-rc.1  \n" +
+		"v1.1\n" +
+		"   # indented comment\n" +
+		"1.1.0\n" +
+		"not-a-version\n"
+
+	vs, errs := ParseLines(strings.NewReader(input))
+
+	expect := []string{"1.0.0", "1.1.0-rc.1", "1.1.0"}
+	if len(vs) != len(expect) {
+		t.Fatalf("Expected %v, got %v", expect, vs)
+	}
+	for i := range expect {
+		if vs[i].Semver() != expect[i] {
+			t.Errorf("Expected %s at %d, got %s", expect[i], i, vs[i])
+		}
+	}
+
+	if len(errs) != 2 {
+		t.Fatalf("Expected 2 errors, got %v", errs)
+	}
+	for i, line := range []string{"line 5:", "line 8:"} {
+		if !strings.Contains(errs[i].Error(), line) {
+			t.Errorf("Expected error %d to name %s, got %v", i, line, errs[i])
+		}
+		if !errors.Is(errs[i], ErrInvalidVersion) {
+			t.Errorf("Expected %v to wrap ErrInvalidVersion", errs[i])
+		}
+	}
+}
+
+func TestParseLinesEmpty(t *testing.T) {
+	vs, errs := ParseLines(strings.NewReader("\n# nothing here\n"))
+	if len(vs) != 0 || len(errs) != 0 {
+		t.Errorf("Expected no versions or errors, got %v and %v", vs, errs)
+	}
+}
+
+func TestParseVersionStringEmpty(t *testing.T) {
+	if _, err := ParseVersionString(""); err != ErrEmptyVersion {
+		t.Errorf("Expected ErrEmptyVersion, got %v", err)
+	}
+}
+
+func TestComponentRange(t *testing.T) {
+	tests := map[string]string{
+		"9223372036854775808.0.0":     "major",
+		"1.99999999999999999999999.0": "minor",
+		"1.2.18446744073709551616-rc": "patch",
+	}
+
+	for s, component := range tests {
+		_, err := ParseVersionString(s)
+		var rangeErr *ComponentRangeError
+		if !errors.As(err, &rangeErr) {
+			t.Errorf("Expected a ComponentRangeError for %s, got %v", s, err)
+			continue
+		}
+		if rangeErr.Component != component {
+			t.Errorf("Expected the %s component to be reported for %s, got %s", component, s, rangeErr.Component)
+		}
+		if !errors.Is(err, ErrInvalidVersion) {
+			t.Errorf("Expected %v to wrap ErrInvalidVersion", err)
+		}
+
+		vconf := testConfig()
+		vconf.VersionString = s
+		if _, err := NewVersion(&vconf); !errors.As(err, &rangeErr) {
+			t.Errorf("Expected a ComponentRangeError from NewVersion for %s, got %v", s, err)
+		}
+	}
+}
+
+func TestComponentRangeMax(t *testing.T) {
+	for _, s := range []string{"20240214.1.0", "9223372036854775807.0.0"} {
+		v, err := ParseVersionString(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v.Semver() != s {
+			t.Errorf("Expected %s, got %s", s, v.Semver())
+		}
+	}
+
+	v := testVersion(t, "9223372036854775807.0.0")
+	if uint64(v.semver.Major) != MaxComponent {
+		t.Errorf("Expected %d, got %d", uint64(MaxComponent), v.semver.Major)
+	}
+}
+
+func TestFromOCILabels(t *testing.T) {
+	labels := map[string]string{
+		OCILabelVersion:                     "1.2.3",
+		OCILabelRevision:                    "de6e4f2f6afbe97e9e96c12efef66fb4b65a0a3d",
+		OCILabelCreated:                     "2019-02-14T15:04:05Z",
+		"org.opencontainers.image.licenses": "MIT",
+	}
+
+	v, err := FromOCILabels(labels)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Semver() != labels[OCILabelVersion] {
+		t.Errorf("Expected %s, got %s", labels[OCILabelVersion], v.Semver())
+	}
+	if v.GitHash() != labels[OCILabelRevision] {
+		t.Errorf("Expected %s, got %s", labels[OCILabelRevision], v.GitHash())
+	}
+	if v.TStamp() != labels[OCILabelCreated] {
+		t.Errorf("Expected %s, got %s", labels[OCILabelCreated], v.TStamp())
+	}
+	var codes []string
+	for _, w := range v.StructuredWarnings() {
+		codes = append(codes, w.Code)
+	}
+	if len(codes) != 1 || codes[0] != WarningNonProduction {
+		t.Errorf("Expected only the non-production warning, got %v", v.Warnings())
+	}
+}
+
+func TestFromOCILabelsMinimal(t *testing.T) {
+	v, err := FromOCILabels(map[string]string{OCILabelVersion: "1.2.3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.GitHash() != "" || v.TStampUnix() != 0 {
+		t.Errorf("Expected empty git and timestamp fields, got %#v", v)
+	}
+}
+
+func TestFromOCILabelsInvalid(t *testing.T) {
+	if _, err := FromOCILabels(map[string]string{}); err != ErrEmptyVersion {
+		t.Errorf("Expected ErrEmptyVersion, got %v", err)
+	}
+
+	labels := map[string]string{OCILabelVersion: "1.2.3", OCILabelCreated: "yesterday"}
+	if _, err := FromOCILabels(labels); err == nil {
+		t.Error("Expected an error for an invalid created label")
+	}
+}
+
+func TestParseVersionStringWhitespace(t *testing.T) {
+	expect := "1.2.3"
+
+	for _, s := range []string{"1.2.3\n", " 1.2.3 ", "1.2.3", "1.2.3\r\n", "1.2.3\r"} {
+		v, err := ParseVersionString(s)
+		if err != nil {
+			t.Errorf("Expected %q to parse, got %s", s, err)
+			continue
+		}
+		if v.Semver() != expect {
+			t.Errorf("Expected %s, got %s", expect, v.Semver())
+		}
+	}
+}
+
+const goVersionM = "./bin/app: go1.22.1\n" +
+	"\tpath\texample.com/app\n" +
+	"\tmod\texample.com/app\tv1.2.3\th1:abc=\n" +
+	"\tdep\tgithub.com/blang/semver\tv3.5.1+incompatible\th1:def=\n" +
+	"\tbuild\t-compiler=gc\n" +
+	"\tbuild\tCGO_ENABLED=0\n" +
+	"\tbuild\tGOARCH=arm64\n" +
+	"\tbuild\tGOOS=darwin\n" +
+	"\tbuild\tvcs=git\n" +
+	"\tbuild\tvcs.revision=de6e4f2a1b2c3d4e5f6a7b8c9d0e1f2a3b4c5d6e\n" +
+	"\tbuild\tvcs.time=2019-02-14T15:04:05Z\n" +
+	"\tbuild\tvcs.modified=false\n"
+
+func TestFromGoVersionM(t *testing.T) {
+	v, err := FromGoVersionM(goVersionM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string][2]string{
+		"version":  {"1.2.3", v.Semver()},
+		"compiler": {"go1.22.1", v.Compiler()},
+		"os":       {"darwin", v.OS()},
+		"arch":     {"arm64", v.Arch()},
+		"git_hash": {"de6e4f2a1b2c3d4e5f6a7b8c9d0e1f2a3b4c5d6e", v.GitHash()},
+		"time":     {"2019-02-14T15:04:05Z", v.TStamp()},
+	}
+	for field, test := range tests {
+		if test[0] != test[1] {
+			t.Errorf("Expected %s %s, got %s", field, test[0], test[1])
+		}
+	}
+	var codes []string
+	for _, w := range v.StructuredWarnings() {
+		codes = append(codes, w.Code)
+	}
+	if len(codes) != 1 || codes[0] != WarningNonProduction {
+		t.Errorf("Expected only the non-production warning, got %v", v.Warnings())
+	}
+}
+
+func TestFromGoVersionMNoVCS(t *testing.T) {
+	output := "app: go1.22.1\r\n" +
+		"\tpath\texample.com/app\r\n" +
+		"\tmod\texample.com/app\t(devel)\t\r\n" +
+		"\tbuild\tGOARCH=amd64\r\n" +
+		"\tbuild\tGOOS=linux\r\n"
+
+	v, err := FromGoVersionM(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Semver() != "0.0.0" || v.OS() != "linux" || v.Arch() != "amd64" || v.GitHash() != "" {
+		t.Errorf("Expected a partial 0.0.0 linux/amd64 version, got %#v", v)
+	}
+
+	codes := map[string]bool{}
+	for _, w := range v.StructuredWarnings() {
+		codes[w.Code] = true
+	}
+	if !codes[WarningNoVCS] || !codes[WarningEmptyVersion] {
+		t.Errorf("Expected no-VCS and empty version warnings, got %v", v.Warnings())
+	}
+}
+
+func TestFromGoVersionMInvalid(t *testing.T) {
+	for _, output := range []string{
+		"",
+		"not go version output",
+		"app: go1.22.1\n\tmod\texample.com/app\tnot-a-version\n",
+		"app: go1.22.1\n\tmod\texample.com/app\tv1.2.3\n\tbuild\tvcs.time=yesterday\n",
+	} {
+		if _, err := FromGoVersionM(output); err == nil {
+			t.Errorf("Expected an error for %q", output)
+		}
+	}
+}
+
+func TestExtractVersion(t *testing.T) {
+	tests := map[string]string{
+		"myapp version 1.2.3 (abc)":                  "1.2.3",
+		"MyApp version: 1.2.3-rc.1+ci.1234\nGit...":  "1.2.3-rc.1+ci.1234",
+		"tool v2.0.1, built with go1.11.5":           "2.0.1",
+		"2.1.0":                                      "2.1.0",
+		"upgrade from 1.2 to 1.4.0-beta.2 is needed": "1.4.0-beta.2",
+	}
+
+	for text, expect := range tests {
+		v, err := ExtractVersion(text)
+		if err != nil {
+			t.Errorf("Expected a version in %q, got %s", text, err)
+			continue
+		}
+		if v.Semver() != expect {
+			t.Errorf("Expected %s in %q, got %s", expect, text, v.Semver())
+		}
+	}
+}
+
+func TestExtractVersionNone(t *testing.T) {
+	for _, text := range []string{"", "myapp version unknown", "built with go1.11"} {
+		if _, err := ExtractVersion(text); !errors.Is(err, ErrInvalidVersion) {
+			t.Errorf("Expected ErrInvalidVersion for %q, got %v", text, err)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package govee
+
+// knownOS and knownArch list the GOOS and GOARCH values known to the Go
+// toolchain, including ones reserved for ports that aren't supported.
+var (
+	knownOS = map[string]bool{
+		"aix": true, "android": true, "darwin": true, "dragonfly": true,
+		"freebsd": true, "hurd": true, "illumos": true, "ios": true,
+		"js": true, "linux": true, "nacl": true, "netbsd": true,
+		"openbsd": true, "plan9": true, "solaris": true, "wasip1": true,
+		"windows": true, "zos": true,
+	}
+	knownArch = map[string]bool{
+		"386": true, "amd64": true, "amd64p32": true, "arm": true,
+		"armbe": true, "arm64": true, "arm64be": true, "loong64": true,
+		"mips": true, "mipsle": true, "mips64": true, "mips64le": true,
+		"mips64p32": true, "mips64p32le": true, "ppc": true, "ppc64": true,
+		"ppc64le": true, "riscv": true, "riscv64": true, "s390": true,
+		"s390x": true, "sparc": true, "sparc64": true, "wasm": true,
+	}
+)
+
+// IsWindows reports whether the version was built for Windows.
+func (v Version) IsWindows() bool {
+	return v.os == "windows"
+}
+
+// IsARM reports whether the version was built for a 32 or 64-bit ARM
+// architecture.
+func (v Version) IsARM() bool {
+	switch v.arch {
+	case "arm", "armbe", "arm64", "arm64be":
+		return true
+	}
+	return false
+}
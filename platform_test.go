@@ -0,0 +1,85 @@
+package govee
+
+import "testing"
+
+func TestPlatformWarnings(t *testing.T) {
+	tests := []struct {
+		os, arch string
+		expect   []string
+	}{
+		{"linux", "amd64", nil},
+		{"windows", "386", nil},
+		{"darwin", "arm64", nil},
+		{"", "", nil},
+		{"linx", "amd64", []string{WarningUnknownOS}},
+		{"linux", "amd86", []string{WarningUnknownArch}},
+		{"Linux", "x86_64", []string{WarningUnknownOS, WarningUnknownArch}},
+	}
+
+	for _, test := range tests {
+		vconf := testConfig()
+		vconf.OS = test.os
+		vconf.Arch = test.arch
+		v, err := NewVersion(&vconf)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var codes []string
+		for _, w := range v.StructuredWarnings() {
+			codes = append(codes, w.Code)
+		}
+		if len(codes) != len(test.expect) {
+			t.Errorf("Expected %v for %s/%s, got %v", test.expect, test.os, test.arch, codes)
+			continue
+		}
+		for i := range codes {
+			if codes[i] != test.expect[i] {
+				t.Errorf("Expected %v for %s/%s, got %v", test.expect, test.os, test.arch, codes)
+			}
+		}
+	}
+}
+
+func TestIsWindows(t *testing.T) {
+	tests := map[string]bool{
+		"windows": true,
+		"linux":   false,
+		"darwin":  false,
+		"":        false,
+	}
+
+	for os, expect := range tests {
+		vconf := testConfig()
+		vconf.OS = os
+		v, err := NewVersion(&vconf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v.IsWindows() != expect {
+			t.Errorf("Expected IsWindows for %q to be %t", os, expect)
+		}
+	}
+}
+
+func TestIsARM(t *testing.T) {
+	tests := map[string]bool{
+		"arm":   true,
+		"arm64": true,
+		"amd64": false,
+		"386":   false,
+		"":      false,
+	}
+
+	for arch, expect := range tests {
+		vconf := testConfig()
+		vconf.Arch = arch
+		v, err := NewVersion(&vconf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v.IsARM() != expect {
+			t.Errorf("Expected IsARM for %q to be %t", arch, expect)
+		}
+	}
+}
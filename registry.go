@@ -0,0 +1,40 @@
+package govee
+
+import "sync"
+
+// Registry maps component names to their versions. It is safe for concurrent
+// use, and the zero value is ready to use.
+type Registry struct {
+	mu       sync.RWMutex
+	versions map[string]Version
+}
+
+// Register records the version of the named component, replacing any version
+// previously registered under the same name.
+func (r *Registry) Register(name string, v Version) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.versions == nil {
+		r.versions = make(map[string]Version)
+	}
+	r.versions[name] = v
+}
+
+// Lookup returns the version registered under name, and whether it was found.
+func (r *Registry) Lookup(name string) (Version, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	v, ok := r.versions[name]
+	return v, ok
+}
+
+// All returns a copy of all registered versions keyed by component name.
+func (r *Registry) All() map[string]Version {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	all := make(map[string]Version, len(r.versions))
+	for name, v := range r.versions {
+		all[name] = v
+	}
+	return all
+}
@@ -0,0 +1,82 @@
+package govee
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestRegistryLookup(t *testing.T) {
+	expect := "1.2.3"
+
+	vconf := testConfig()
+	v, err := NewVersion(&vconf)
+	if err != nil {
+		t.Error(err)
+	}
+
+	r := Registry{}
+	r.Register("core", v)
+
+	got, ok := r.Lookup("core")
+	if !ok {
+		t.Fatal("Expected core to be registered")
+	}
+	if got.Semver() != expect {
+		t.Errorf("Expected %s, got %s", expect, got.Semver())
+	}
+	if _, ok := r.Lookup("missing"); ok {
+		t.Error("Expected missing not to be registered")
+	}
+}
+
+func TestRegistryOverwrite(t *testing.T) {
+	expect := "2.0.0"
+
+	r := Registry{}
+	for _, s := range []string{"1.2.3", "2.0.0"} {
+		vconf := testConfig()
+		vconf.VersionString = s
+		v, err := NewVersion(&vconf)
+		if err != nil {
+			t.Error(err)
+		}
+		r.Register("plugin", v)
+	}
+
+	got, _ := r.Lookup("plugin")
+	if got.Semver() != expect {
+		t.Errorf("Expected %s, got %s", expect, got.Semver())
+	}
+	if len(r.All()) != 1 {
+		t.Errorf("Expected 1 version, got %d", len(r.All()))
+	}
+}
+
+func TestRegistryConcurrent(t *testing.T) {
+	expect := 50
+
+	vconf := testConfig()
+	v, err := NewVersion(&vconf)
+	if err != nil {
+		t.Error(err)
+	}
+
+	r := Registry{}
+	var wg sync.WaitGroup
+	for i := 0; i < expect; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			name := fmt.Sprintf("plugin-%d", i)
+			r.Register(name, v)
+			r.Lookup(name)
+			r.All()
+		}(i)
+	}
+	wg.Wait()
+
+	if len(r.All()) != expect {
+		t.Errorf("Expected %d versions, got %d", expect, len(r.All()))
+	}
+}
@@ -0,0 +1,32 @@
+package govee
+
+// Versioner is implemented by types that provide version information.
+type Versioner interface {
+	String() string
+	Semver() string
+	Major() int
+	Minor() int
+	Patch() int
+	Pre() string
+	Warnings() []string
+	Err() error
+	GitHash() string
+	GitBranch() string
+	GitUser() string
+	OS() string
+	Arch() string
+	Compiler() string
+	Release() string
+	TStamp() string
+}
+
+var _ Versioner = Version{}
+
+// New creates a new version from a VersionConfig, returned as a Versioner.
+func New(c *VersionConfig) (Versioner, error) {
+	v, err := NewVersion(c)
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
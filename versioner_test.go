@@ -0,0 +1,40 @@
+package govee
+
+import "testing"
+
+func TestNew(t *testing.T) {
+	expect := "1.2.3"
+
+	vconf := testConfig()
+	var v Versioner
+	v, err := New(&vconf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v.Semver() != expect {
+		t.Errorf("Expected %s, got %s", expect, v.Semver())
+	}
+	if v.GitHash() != vconf.GitHash {
+		t.Errorf("Expected %s, got %s", vconf.GitHash, v.GitHash())
+	}
+	if v.Pre() != "" {
+		t.Errorf("Expected no pre-release, got %s", v.Pre())
+	}
+	if v.Err() != nil {
+		t.Errorf("Expected no error, got %s", v.Err())
+	}
+}
+
+func TestNewInvalid(t *testing.T) {
+	vconf := testConfig()
+	vconf.VersionString = "not-a-version"
+
+	v, err := New(&vconf)
+	if err == nil {
+		t.Error("Expected an error for an invalid version string")
+	}
+	if v != nil {
+		t.Errorf("Expected a nil Versioner, got %#v", v)
+	}
+}
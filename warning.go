@@ -0,0 +1,121 @@
+package govee
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+)
+
+// Warning codes identify the check that produced a warning.
+const (
+	WarningEmptyVersion       = "empty-version"
+	WarningPreRelease         = "pre-release"
+	WarningInitialDevelopment = "initial-development"
+	WarningNonProduction      = "non-production"
+	WarningBranchRelease      = "branch-release-mismatch"
+	WarningDetachedHead       = "detached-head"
+	WarningTagMismatch        = "tag-mismatch"
+	WarningInvalidTimestamp   = "invalid-timestamp"
+	WarningInvalidGitHash     = "invalid-git-hash"
+	WarningOldGoVersion       = "old-go-version"
+	WarningNoVCS              = "no-vcs-info"
+	WarningUnknownOS          = "unknown-os"
+	WarningUnknownArch        = "unknown-arch"
+)
+
+// Warning severities. Advisories describe builds that are valid but not meant
+// for production, while critical warnings point at missing or malformed
+// version information.
+const (
+	SeverityAdvisory = "advisory"
+	SeverityCritical = "critical"
+)
+
+// Warning is a structured version warning.
+type Warning struct {
+	Code     string `json:"code"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// StructuredWarnings returns the version warnings with their codes and
+// severities.
+func (v Version) StructuredWarnings() []Warning {
+	return append([]Warning(nil), v.warnings...)
+}
+
+// WarningsJSON returns the structured warnings as a JSON array. A version
+// without warnings produces an empty array.
+func (v Version) WarningsJSON() ([]byte, error) {
+	warnings := v.StructuredWarnings()
+	if warnings == nil {
+		warnings = []Warning{}
+	}
+	return json.Marshal(warnings)
+}
+
+// Exit is called by FatalOnWarnings to terminate the program. It may be
+// replaced, for example in tests.
+var Exit = os.Exit
+
+// FatalOnWarnings writes each of the version's warnings to w, one per line,
+// and then calls Exit(1). It does nothing if there are no warnings.
+func FatalOnWarnings(v Version, w io.Writer) {
+	warnings := v.Warnings()
+	if len(warnings) == 0 {
+		return
+	}
+	for _, warning := range warnings {
+		fmt.Fprintln(w, warning)
+	}
+	Exit(1)
+}
+
+// LogWarnings writes each of the version's warnings to l, one per call to
+// l.Println, so they carry the logger's prefix and flags. It does nothing if l
+// is nil or there are no warnings.
+func (v Version) LogWarnings(l *log.Logger) {
+	if l == nil {
+		return
+	}
+	for _, warning := range v.Warnings() {
+		l.Println(warning)
+	}
+}
+
+// ReproducibilityWarnings lists the version fields that stop two builds of
+// the same source from being byte-for-byte identical: a git user, a
+// timestamp that is not in UTC, and a CI run ID or URL. It is advisory and
+// doesn't affect Warnings. A version without such fields returns nil.
+func (v Version) ReproducibilityWarnings() []string {
+	var warnings []string
+	if v.gituser != "" {
+		warnings = append(warnings, fmt.Sprintf("The git user \"%s\" identifies who built the binary.", v.gituser))
+	}
+	if !v.timestamp.IsZero() {
+		if name, offset := v.timestamp.Zone(); name != "UTC" || offset != 0 {
+			warnings = append(warnings, fmt.Sprintf("The timestamp is in the local time zone \"%s\" of the build machine.", name))
+		}
+	}
+	if v.cirunid != "" || v.cirunurl != "" {
+		warnings = append(warnings, "The CI run ID and URL differ for every build.")
+	}
+	return warnings
+}
+
+// WarningLevel summarises the warnings as a single number for health checks:
+// 0 if there are none, 1 if they are all advisories, such as a pre-release or
+// non-production build, and 2 if any is critical, such as an invalid git
+// hash.
+func (v Version) WarningLevel() int {
+	level := 0
+	for _, w := range v.warnings {
+		if w.Severity == SeverityCritical {
+			return 2
+		}
+		level = 1
+	}
+	return level
+}
@@ -0,0 +1,336 @@
+package govee
+
+import (
+	"log"
+	"strings"
+	"testing"
+)
+
+func TestStructuredWarnings(t *testing.T) {
+	expect := []Warning{
+		{WarningPreRelease, SeverityAdvisory, "This version is tagged as a pre-release \"[rc 1]\". Please don't use in production."},
+		{WarningNonProduction, SeverityAdvisory, "This version is tagged as release \"test\". Please don't use in production."},
+	}
+
+	vconf := testConfig()
+	vconf.VersionString = "1.2.3-rc.1"
+	vconf.Release = "test"
+	v, err := NewVersion(&vconf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	warnings := v.StructuredWarnings()
+	if len(warnings) != len(expect) {
+		t.Fatalf("Expected %d warnings, got %d", len(expect), len(warnings))
+	}
+	for i := range expect {
+		if warnings[i] != expect[i] {
+			t.Errorf("Expected %#v, got %#v", expect[i], warnings[i])
+		}
+	}
+}
+
+func TestWarningsJSON(t *testing.T) {
+	expect := `[{"code":"pre-release","severity":"advisory",` +
+		`"message":"This version is tagged as a pre-release \"[rc 1]\". Please don't use in production."},` +
+		`{"code":"non-production","severity":"advisory",` +
+		`"message":"This version is tagged as release \"test\". Please don't use in production."}]`
+
+	vconf := testConfig()
+	vconf.VersionString = "1.2.3-rc.1"
+	vconf.Release = "test"
+	v, err := NewVersion(&vconf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := v.WarningsJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != expect {
+		t.Errorf("Expected %s, got %s", expect, b)
+	}
+}
+
+func TestWarningsJSONEmpty(t *testing.T) {
+	expect := "[]"
+
+	v := testVersion(t, "1.2.3")
+	b, err := v.WarningsJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != expect {
+		t.Errorf("Expected %s, got %s", expect, b)
+	}
+}
+
+func TestWarn0x(t *testing.T) {
+	tests := map[string]int{
+		"0.9.0":      1,
+		"0.0.1":      1,
+		"1.0.0":      0,
+		"1.0.0-rc.1": 0,
+	}
+
+	for in, expect := range tests {
+		vconf := testConfig()
+		vconf.VersionString = in
+		vconf.Warn0x = true
+		v, err := NewVersion(&vconf)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		count := 0
+		for _, w := range v.StructuredWarnings() {
+			if w.Code == WarningInitialDevelopment {
+				count++
+			}
+		}
+		if count != expect {
+			t.Errorf("Expected %d initial development warnings for %s, got %v", expect, in, v.Warnings())
+		}
+	}
+}
+
+func TestWarn0xDisabled(t *testing.T) {
+	v := testVersion(t, "0.9.0")
+	for _, w := range v.StructuredWarnings() {
+		if w.Code == WarningInitialDevelopment {
+			t.Errorf("Expected no initial development warning, got %s", w.Message)
+		}
+	}
+}
+
+func TestBranchReleases(t *testing.T) {
+	pairings := map[string][]string{
+		"master":    {"prod", "production"},
+		"feature/*": {"test", "dev"},
+	}
+
+	tests := []struct {
+		branch, release string
+		expect          int
+	}{
+		{"master", "prod", 0},
+		{"master", "hotfix", 1},
+		{"feature/login", "test", 0},
+		{"feature/login", "prod", 1},
+		{"testing", "prod", 0},
+	}
+
+	for _, test := range tests {
+		vconf := testConfig()
+		vconf.GitBranch = test.branch
+		vconf.Release = test.release
+		vconf.BranchReleases = pairings
+		v, err := NewVersion(&vconf)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		count := 0
+		for _, w := range v.StructuredWarnings() {
+			if w.Code == WarningBranchRelease {
+				count++
+			}
+		}
+		if count != test.expect {
+			t.Errorf("Expected %d mismatch warnings for %s/%s, got %v", test.expect, test.branch, test.release, v.Warnings())
+		}
+	}
+}
+
+func TestFatalOnWarnings(t *testing.T) {
+	expect := "This version is tagged as release \"test\". Please don't use in production.\n"
+
+	var code []int
+	defer func(exit func(int)) { Exit = exit }(Exit)
+	Exit = func(c int) { code = append(code, c) }
+
+	vconf := testConfig()
+	vconf.Release = "test"
+	v, err := NewVersion(&vconf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var b strings.Builder
+	FatalOnWarnings(v, &b)
+	if b.String() != expect {
+		t.Errorf("Expected %q, got %q", expect, b.String())
+	}
+	if len(code) != 1 || code[0] != 1 {
+		t.Errorf("Expected a single exit with code 1, got %v", code)
+	}
+}
+
+func TestFatalOnWarningsNone(t *testing.T) {
+	exited := false
+	defer func(exit func(int)) { Exit = exit }(Exit)
+	Exit = func(int) { exited = true }
+
+	var b strings.Builder
+	FatalOnWarnings(testVersion(t, "1.2.3"), &b)
+	if exited {
+		t.Error("Expected no exit without warnings")
+	}
+	if b.Len() != 0 {
+		t.Errorf("Expected no output, got %q", b.String())
+	}
+}
+
+func TestLogWarnings(t *testing.T) {
+	expect := "govee: This version is tagged as a pre-release \"[rc 1]\". Please don't use in production.\n" +
+		"govee: This version is tagged as release \"test\". Please don't use in production.\n"
+
+	vconf := testConfig()
+	vconf.VersionString = "1.2.3-rc.1"
+	vconf.Release = "test"
+	v, err := NewVersion(&vconf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var b strings.Builder
+	v.LogWarnings(log.New(&b, "govee: ", 0))
+	if b.String() != expect {
+		t.Errorf("Expected %q, got %q", expect, b.String())
+	}
+}
+
+func TestLogWarningsNone(t *testing.T) {
+	var b strings.Builder
+	testVersion(t, "1.2.3").LogWarnings(log.New(&b, "", 0))
+	if b.Len() != 0 {
+		t.Errorf("Expected no output, got %q", b.String())
+	}
+
+	vconf := testConfig()
+	vconf.Release = "test"
+	v, err := NewVersion(&vconf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v.LogWarnings(nil)
+}
+
+func TestTagMismatch(t *testing.T) {
+	tests := []struct {
+		version, tag string
+		expect       int
+	}{
+		{"1.2.3", "semver/1.2.3", 0},
+		{"1.2.3", "v1.2.3", 0},
+		{"1.2.3+ci.1", "1.2.3", 0},
+		{"1.2.3", "semver/1.2.4", 1},
+		{"1.2.3", "release-candidate", 1},
+		{"1.2.3", "", 0},
+	}
+
+	for _, test := range tests {
+		vconf := testConfig()
+		vconf.VersionString = test.version
+		vconf.GitTag = test.tag
+		v, err := NewVersion(&vconf)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		count := 0
+		for _, w := range v.StructuredWarnings() {
+			if w.Code == WarningTagMismatch {
+				count++
+			}
+		}
+		if count != test.expect {
+			t.Errorf("Expected %d mismatch warnings for %s/%s, got %v", test.expect, test.version, test.tag, v.Warnings())
+		}
+	}
+}
+
+func TestTagMismatchNoVersion(t *testing.T) {
+	vconf := testConfig()
+	vconf.VersionString = ""
+	vconf.VersionTolerant = true
+	vconf.GitTag = "semver/1.2.3"
+	v, err := NewVersion(&vconf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, w := range v.StructuredWarnings() {
+		if w.Code == WarningTagMismatch {
+			t.Errorf("Expected no mismatch warning without a version string, got %s", w.Message)
+		}
+	}
+}
+
+func TestReproducibilityWarnings(t *testing.T) {
+	vconf := testConfig()
+	vconf.GitUser = ""
+	v, err := NewVersion(&vconf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if w := v.ReproducibilityWarnings(); len(w) != 0 {
+		t.Errorf("Expected no reproducibility warnings, got %v", w)
+	}
+}
+
+func TestReproducibilityWarningsDirty(t *testing.T) {
+	expect := []string{
+		"The git user \"Jane Doe\" identifies who built the binary.",
+		"The timestamp is in the local time zone \"SAST\" of the build machine.",
+		"The CI run ID and URL differ for every build.",
+	}
+
+	vconf := testConfig()
+	vconf.TStamp = "Thu Feb 14 15:04:05 SAST 2019"
+	vconf.CIRunID = "1234"
+	v, err := NewVersion(&vconf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := v.ReproducibilityWarnings()
+	if len(w) != len(expect) {
+		t.Fatalf("Expected %v, got %v", expect, w)
+	}
+	for i := range expect {
+		if w[i] != expect[i] {
+			t.Errorf("Expected %s, got %s", expect[i], w[i])
+		}
+	}
+}
+
+func TestWarningLevel(t *testing.T) {
+	tests := []struct {
+		version, release, hash string
+		expect                 int
+	}{
+		{"1.2.3", "prod", "de6e4f2", 0},
+		{"1.2.3-rc.1", "prod", "de6e4f2", 1},
+		{"1.2.3", "test", "de6e4f2", 1},
+		{"1.2.3", "prod", "unknown", 2},
+		{"1.2.3-rc.1", "test", "unknown", 2},
+	}
+
+	for _, test := range tests {
+		vconf := testConfig()
+		vconf.VersionString = test.version
+		vconf.Release = test.release
+		vconf.GitHash = test.hash
+		v, err := NewVersion(&vconf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v.WarningLevel() != test.expect {
+			t.Errorf("Expected level %d for %s/%s/%s, got %d", test.expect, test.version, test.release, test.hash, v.WarningLevel())
+		}
+	}
+}